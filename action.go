@@ -16,55 +16,28 @@ limitations under the License.
 
 package trello
 
-import "encoding/json"
+import (
+	"context"
+	"encoding/json"
+	"iter"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxActionsPageSize - Most actions Trello returns for a single request
+const MaxActionsPageSize = 1000
 
 // Action struct
 type Action struct {
 	client          *Client
-	ID              string `json:"id"`
-	IDMemberCreator string `json:"idMemberCreator"`
-	Data            struct {
-		DateLastEdited string `json:"dateLastEdited"`
-		ListBefore     struct {
-			ID   string `json:"id"`
-			Name string `json:"name"`
-		} `json:"listBefore"`
-		ListAfter struct {
-			ID   string `json:"id"`
-			Name string `json:"name"`
-		} `json:"listAfter"`
-		CheckItem struct {
-			ID    string `json:"id"`
-			State string `json:"state"`
-			Name  string `json:"name"`
-		} `json:"checkItem"`
-		CheckList struct {
-			ID   string `json:"id"`
-			Name string `json:"name"`
-		} `json:"checklist"`
-		List struct {
-			ID   string `json:"id"`
-			Name string `json:"name"`
-		} `json:"list"`
-		TextData struct {
-			Emoji struct{} `json:"emoji"`
-		} `json:"textData"`
-		Board struct {
-			ID        string `json:"id"`
-			Name      string `json:"name"`
-			ShortLink string `json:"shortLink"`
-		} `json:"board"`
-		Card struct {
-			ID        string `json:"id"`
-			Name      string `json:"name"`
-			ShortLink string `json:"shortLink"`
-			IDShort   int    `json:"idShort"`
-		} `json:"card"`
-		Text string `json:"text"`
-	} `json:"data"`
-	Type          ActionType `json:"type"`
-	Date          string     `json:"date"`
-	MemberCreator struct {
+	ID              string     `json:"id"`
+	IDMemberCreator string     `json:"idMemberCreator"`
+	Data            ActionData `json:"data"`
+	Type            ActionType `json:"type"`
+	Date            time.Time  `json:"date"`
+	MemberCreator   struct {
 		ID         string `json:"id"`
 		AvatarHash string `json:"avatarHash"`
 		FullName   string `json:"fullName"`
@@ -99,6 +72,7 @@ const (
 	CreateBoardInvitation             ActionType = "createBoardInvitation"
 	CreateBoardPreference             ActionType = "createBoardPreference"
 	CreateCard                        ActionType = "createCard"
+	CreateCustomField                 ActionType = "createCustomField"
 	CreateChecklist                   ActionType = "createChecklist"
 	CreateList                        ActionType = "createList"
 	CreateOrganization                ActionType = "createOrganization"
@@ -107,6 +81,7 @@ const (
 	DeleteBoardInvitation             ActionType = "deleteBoardInvitation"
 	DeleteCard                        ActionType = "deleteCard"
 	DeleteCheckItem                   ActionType = "deleteCheckItem"
+	DeleteCustomField                 ActionType = "deleteCustomField"
 	DeleteLabel                       ActionType = "deleteLabel"
 	DeleteOrganizationInvitation      ActionType = "deleteOrganizationInvitation"
 	DisablePlugin                     ActionType = "disablePlugin"
@@ -139,6 +114,8 @@ const (
 	UpdateCard                        ActionType = "updateCard"
 	UpdateCheckItem                   ActionType = "updateCheckItem"
 	UpdateCheckItemStateOnCard        ActionType = "updateCheckItemStateOnCard"
+	UpdateCustomField                 ActionType = "updateCustomField"
+	UpdateCustomFieldItem             ActionType = "updateCustomFieldItem"
 	UpdateChecklist                   ActionType = "updateChecklist"
 	UpdateLabel                       ActionType = "updateLabel"
 	UpdateList                        ActionType = "updateList"
@@ -147,6 +124,52 @@ const (
 	VoteOnCard                        ActionType = "voteOnCard"
 )
 
+// Action - Get Action by id (string)
+// - https://developer.atlassian.com/cloud/trello/rest/api-group-actions/#api-actions-id-get
+func (c *Client) Action(actionID string) (action *Action, err error) {
+	return c.ActionContext(context.Background(), actionID)
+}
+
+// ActionContext - Get Action by id (with context)
+func (c *Client) ActionContext(ctx context.Context, actionID string) (action *Action, err error) {
+	action = &Action{}
+	body, err := c.GetContext(ctx, "/actions/"+actionID)
+	if err == nil {
+		err = parseAction(body, action, c)
+	}
+	return
+}
+
+// EditComment - Change the text of a comment (commentCard Action)
+// Only the member who wrote a comment can edit it.
+// - https://developer.atlassian.com/cloud/trello/rest/api-group-actions/#api-actions-id-text-put
+func (a *Action) EditComment(text string) (err error) {
+	return a.EditCommentContext(context.Background(), text)
+}
+
+// EditCommentContext - Change the text of a comment (with context)
+func (a *Action) EditCommentContext(ctx context.Context, text string) (err error) {
+	payload := url.Values{}
+	payload.Set("value", text)
+	body, err := a.client.PutContext(ctx, "/actions/"+a.ID+"/text", payload)
+	if err == nil {
+		err = parseAction(body, a, a.client)
+	}
+	return
+}
+
+// Delete - Delete an Action; only comments can be deleted
+// - https://developer.atlassian.com/cloud/trello/rest/api-group-actions/#api-actions-id-delete
+func (a *Action) Delete() (err error) {
+	return a.DeleteContext(context.Background())
+}
+
+// DeleteContext - Delete an Action (with context)
+func (a *Action) DeleteContext(ctx context.Context) (err error) {
+	_, err = a.client.DeleteContext(ctx, "/actions/"+a.ID)
+	return
+}
+
 func parseAction(body []byte, action *Action, client *Client) (err error) {
 	err = json.Unmarshal(body, &action)
 	if err == nil {
@@ -162,3 +185,74 @@ func parseListActions(body []byte, client *Client) (actions []Action, err error)
 	}
 	return
 }
+
+// ActionFilter - Options for iterating over actions with ActionsIter
+type ActionFilter struct {
+	// Types restricts the actions returned (all types if empty)
+	Types []ActionType
+	// Before starts the iteration before an action ID or RFC 3339 date (newest action if empty)
+	Before string
+	// Since stops the iteration at the cutoff date (no cutoff if zero)
+	Since time.Time
+	// SinceID stops the iteration at an action ID, excluding it (takes precedence over Since)
+	SinceID string
+	// PageSize is the number of actions fetched per request (MaxActionsPageSize if zero)
+	PageSize int
+}
+
+// values - Encode the filter as query parameters for a page ending before the cursor
+func (f ActionFilter) values(before string) url.Values {
+	v := url.Values{}
+	if len(f.Types) > 0 {
+		types := make([]string, len(f.Types))
+		for i, t := range f.Types {
+			types[i] = string(t)
+		}
+		v.Set("filter", strings.Join(types, ","))
+	}
+	if before != "" {
+		v.Set("before", before)
+	}
+	if f.SinceID != "" {
+		v.Set("since", f.SinceID)
+	} else if !f.Since.IsZero() {
+		v.Set("since", f.Since.UTC().Format(time.RFC3339Nano))
+	}
+	v.Set("limit", strconv.Itoa(f.pageSize()))
+	return v
+}
+
+func (f ActionFilter) pageSize() int {
+	if f.PageSize <= 0 || f.PageSize > MaxActionsPageSize {
+		return MaxActionsPageSize
+	}
+	return f.PageSize
+}
+
+// actionsIter - Iterate over the actions of resource (newest first), following the
+// before cursor one page at a time until the actions are exhausted or the cutoff is reached
+func (c *Client) actionsIter(ctx context.Context, resource string, filter ActionFilter) iter.Seq2[Action, error] {
+	return func(yield func(Action, error) bool) {
+		before := filter.Before
+		for {
+			body, err := c.GetContext(ctx, resource+"?"+filter.values(before).Encode())
+			var actions []Action
+			if err == nil {
+				actions, err = parseListActions(body, c)
+			}
+			if err != nil {
+				yield(Action{}, err)
+				return
+			}
+			for _, action := range actions {
+				if !yield(action, nil) {
+					return
+				}
+			}
+			if len(actions) < filter.pageSize() {
+				return
+			}
+			before = actions[len(actions)-1].ID
+		}
+	}
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trello
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+)
+
+// ActionData - What an action was about; which fields are set depends on the action type
+// - https://developer.atlassian.com/cloud/trello/guides/rest-api/action-types/
+type ActionData struct {
+	Text           string     `json:"text"`
+	DateLastEdited *time.Time `json:"dateLastEdited"`
+	TextData       struct {
+		Emoji struct{} `json:"emoji"`
+	} `json:"textData"`
+
+	Board       ActionDataBoard `json:"board"`
+	BoardSource ActionDataBoard `json:"boardSource"`
+	BoardTarget ActionDataBoard `json:"boardTarget"`
+
+	List       ActionDataList `json:"list"`
+	ListBefore ActionDataList `json:"listBefore"`
+	ListAfter  ActionDataList `json:"listAfter"`
+
+	Card       ActionDataCard `json:"card"`
+	CardSource ActionDataCard `json:"cardSource"`
+
+	CheckList ActionDataChecklist `json:"checklist"`
+	CheckItem ActionDataCheckItem `json:"checkItem"`
+
+	Label           ActionDataLabel        `json:"label"`
+	IDMember        string                 `json:"idMember"`
+	IDMemberAdded   string                 `json:"idMemberAdded"`
+	Member          ActionDataMember       `json:"member"`
+	Attachment      ActionDataAttachment   `json:"attachment"`
+	CustomField     ActionDataCustomField  `json:"customField"`
+	CustomFieldItem CustomFieldItem        `json:"customFieldItem"`
+	Organization    ActionDataOrganization `json:"organization"`
+	Plugin          ActionDataPlugin       `json:"plugin"`
+	Old             ActionOld              `json:"old"`
+}
+
+// ActionDataBoard - Board an action refers to
+type ActionDataBoard struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	ShortLink string `json:"shortLink"`
+}
+
+// ActionDataList - List an action refers to
+type ActionDataList struct {
+	ID     string  `json:"id"`
+	Name   string  `json:"name"`
+	Closed bool    `json:"closed"`
+	Pos    float64 `json:"pos"`
+}
+
+// ActionDataCard - Card an action refers to
+// Besides the identifying fields, updateCard actions set the new value of each changed field.
+type ActionDataCard struct {
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	ShortLink   string     `json:"shortLink"`
+	IDShort     int        `json:"idShort"`
+	IDList      string     `json:"idList"`
+	IDBoard     string     `json:"idBoard"`
+	Desc        string     `json:"desc"`
+	Due         *time.Time `json:"due"`
+	Start       *time.Time `json:"start"`
+	DueComplete bool       `json:"dueComplete"`
+	Pos         float64    `json:"pos"`
+	Closed      bool       `json:"closed"`
+	IDLabels    []string   `json:"idLabels"`
+	IDMembers   []string   `json:"idMembers"`
+}
+
+// ActionDataChecklist - Checklist an action refers to
+type ActionDataChecklist struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// ActionDataCheckItem - Checklist item an action refers to
+type ActionDataCheckItem struct {
+	ID    string `json:"id"`
+	State string `json:"state"`
+	Name  string `json:"name"`
+}
+
+// ActionDataLabel - Label an action refers to
+type ActionDataLabel struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// ActionDataMember - Member an action refers to
+type ActionDataMember struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// ActionDataAttachment - Attachment an action refers to
+type ActionDataAttachment struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	URL          string `json:"url"`
+	PreviewURL   string `json:"previewUrl"`
+	PreviewURL2x string `json:"previewUrl2x"`
+}
+
+// ActionDataCustomField - Custom field an action refers to
+type ActionDataCustomField struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// ActionDataOrganization - Organization an action refers to
+type ActionDataOrganization struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// ActionDataPlugin - Power-Up an action refers to
+type ActionDataPlugin struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// ActionOld - Values fields had before an update action
+// Only the changed fields are present: use Has or Fields rather than testing for nil,
+// since a field may have been null before (e.g. a due date being set).
+type ActionOld struct {
+	Name        *string           `json:"name"`
+	Desc        *string           `json:"desc"`
+	Due         *time.Time        `json:"due"`
+	Start       *time.Time        `json:"start"`
+	DueComplete *bool             `json:"dueComplete"`
+	Pos         *float64          `json:"pos"`
+	Closed      *bool             `json:"closed"`
+	IDList      *string           `json:"idList"`
+	IDBoard     *string           `json:"idBoard"`
+	IDLabels    []string          `json:"idLabels"`
+	IDMembers   []string          `json:"idMembers"`
+	Color       *string           `json:"color"`
+	State       *string           `json:"state"`
+	Value       map[string]string `json:"value"`
+
+	// Raw - Every old value, by field name
+	Raw map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON - Decode the typed fields and keep every value in Raw
+func (o *ActionOld) UnmarshalJSON(body []byte) (err error) {
+	type fields ActionOld
+	var f fields
+	if err = json.Unmarshal(body, &f); err == nil {
+		*o = ActionOld(f)
+		err = json.Unmarshal(body, &o.Raw)
+	}
+	return
+}
+
+// Has - Whether the action changed field
+func (o ActionOld) Has(field string) bool {
+	_, ok := o.Raw[field]
+	return ok
+}
+
+// Fields - Names of the changed fields, sorted
+func (o ActionOld) Fields() (fields []string) {
+	for field := range o.Raw {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trello
+
+import (
+	"testing"
+
+	goblin "github.com/franela/goblin"
+	. "github.com/onsi/gomega"
+)
+
+func TestActionData(t *testing.T) {
+	g := goblin.Goblin(t)
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+
+	g.Describe("Action data tests", func() {
+		var board *Board
+		var lists []List
+		var card *Card
+
+		g.Before(func() {
+			board, err = client.CreateBoard(testName("GoTestTrello-ActionData"))
+			Expect(err).To(BeNil())
+			lists, err = board.Lists()
+			Expect(err).To(BeNil())
+			card, err = lists[0].AddCard(Card{Name: "Before", Desc: "Old description"})
+			Expect(err).To(BeNil())
+		})
+
+		g.It("should decode the old values of updateCard actions", func() {
+			Expect(card.SetName("After")).To(BeNil())
+			Expect(card.MoveToList(lists[1])).To(BeNil())
+
+			actions, err := card.Actions(NewArgument("filter", string(UpdateCard)))
+			Expect(err).To(BeNil())
+			Expect(len(actions)).To(Equal(2))
+
+			moved := actions[0].Data
+			Expect(moved.Old.Has("idList")).To(BeTrue())
+			Expect(*moved.Old.IDList).To(Equal(lists[0].ID))
+			Expect(moved.Card.IDList).To(Equal(lists[1].ID))
+			Expect(moved.ListBefore.ID).To(Equal(lists[0].ID))
+			Expect(moved.ListAfter.ID).To(Equal(lists[1].ID))
+
+			renamed := actions[1].Data
+			Expect(renamed.Old.Fields()).To(Equal([]string{"name"}))
+			Expect(*renamed.Old.Name).To(Equal("Before"))
+			Expect(renamed.Old.Desc).To(BeNil())
+			Expect(renamed.Card.Name).To(Equal("After"))
+			Expect(renamed.Board.ID).To(Equal(board.ID))
+		})
+
+		g.It("should decode labels and members", func() {
+			label, err := card.AddNewLabel("Data", "green")
+			Expect(err).To(BeNil())
+			member, err := client.Member("me")
+			Expect(err).To(BeNil())
+			_, err = card.AddMember(member)
+			Expect(err).To(BeNil())
+
+			actions, err := card.Actions(NewArgument("filter", "addLabelToCard,addMemberToCard"))
+			Expect(err).To(BeNil())
+			for _, action := range actions {
+				switch action.Type {
+				case AddMemberToCard:
+					Expect(action.Data.IDMember).To(Equal(member.ID))
+					Expect(action.Data.Member.ID).To(Equal(member.ID))
+				case AddLabelToCard:
+					Expect(action.Data.Label.ID).To(Equal(label.ID))
+					Expect(action.Data.Label.Color).To(Equal("green"))
+				}
+			}
+		})
+
+		g.It("should decode the data of every action type", func() {
+			action := Action{}
+			err := parseAction([]byte(`{
+				"id": "5f0000000000000000000010",
+				"type": "updateCustomFieldItem",
+				"data": {
+					"board": {"id": "5f0000000000000000000001", "name": "Board", "shortLink": "abc"},
+					"card": {"id": "5f0000000000000000000002", "name": "Card", "due": null, "dueComplete": true, "pos": 16384},
+					"customField": {"id": "5f0000000000000000000003", "name": "Priority", "type": "number"},
+					"customFieldItem": {"id": "5f0000000000000000000004", "idCustomField": "5f0000000000000000000003",
+						"idModel": "5f0000000000000000000002", "modelType": "card", "value": {"number": "3"}},
+					"attachment": {"id": "5f0000000000000000000005", "name": "spec.pdf", "url": "https://example.com/spec.pdf"},
+					"organization": {"id": "5f0000000000000000000006", "name": "Team"},
+					"plugin": {"id": "5f0000000000000000000007", "name": "Power-Up", "url": "https://example.com/plugin"},
+					"old": {"value": {"number": "2"}, "due": null, "pos": 8192, "closed": false, "unknown": [1, 2]}
+				}
+			}`), &action, client)
+			Expect(err).To(BeNil())
+			data := action.Data
+			Expect(data.Card.DueComplete).To(BeTrue())
+			Expect(data.Card.Due).To(BeNil())
+			Expect(data.CustomField.Type).To(Equal("number"))
+			Expect(*data.CustomFieldItem.Value.Number).To(Equal(3.0))
+			Expect(data.Attachment.URL).To(Equal("https://example.com/spec.pdf"))
+			Expect(data.Organization.Name).To(Equal("Team"))
+			Expect(data.Plugin.Name).To(Equal("Power-Up"))
+
+			Expect(data.Old.Fields()).To(Equal([]string{"closed", "due", "pos", "unknown", "value"}))
+			Expect(data.Old.Has("due")).To(BeTrue())
+			Expect(data.Old.Due).To(BeNil())
+			Expect(*data.Old.Pos).To(Equal(8192.0))
+			Expect(*data.Old.Closed).To(BeFalse())
+			Expect(data.Old.Value).To(Equal(map[string]string{"number": "2"}))
+			Expect(string(data.Old.Raw["unknown"])).To(Equal("[1, 2]"))
+			Expect(data.Old.Has("name")).To(BeFalse())
+		})
+
+		g.After(func() {
+			err = board.Delete()
+			Expect(err).To(BeNil())
+		})
+	})
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trello
+
+import (
+	"testing"
+
+	goblin "github.com/franela/goblin"
+	. "github.com/onsi/gomega"
+)
+
+func TestComments(t *testing.T) {
+	g := goblin.Goblin(t)
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+
+	g.Describe("Comment tests", func() {
+		var board *Board
+		var card *Card
+		var comment *Action
+
+		g.Before(func() {
+			board, err = client.CreateBoard(testName("GoTestTrello-Comments"))
+			Expect(err).To(BeNil())
+			lists, err := board.Lists()
+			Expect(err).To(BeNil())
+			card, err = lists[0].AddCard(Card{Name: "Commented"})
+			Expect(err).To(BeNil())
+			Expect(card.SetName("Commented card")).To(BeNil())
+		})
+
+		g.It("should list only the comments on a card", func() {
+			_, err = card.AddComment("First")
+			Expect(err).To(BeNil())
+			comment, err = card.AddComment("Status: running")
+			Expect(err).To(BeNil())
+
+			comments, err := card.Comments()
+			Expect(err).To(BeNil())
+			Expect(len(comments)).To(Equal(2))
+			Expect(comments[0].Data.Text).To(Equal("Status: running"))
+			Expect(comments[1].Data.Text).To(Equal("First"))
+
+			comments, err = card.Comments(NewArgument("limit", "1"))
+			Expect(err).To(BeNil())
+			Expect(len(comments)).To(Equal(1))
+			Expect(comments[0].ID).To(Equal(comment.ID))
+		})
+
+		g.It("should edit a comment", func() {
+			Expect(comment.EditComment("Status: done")).To(BeNil())
+			Expect(comment.Data.Text).To(Equal("Status: done"))
+			Expect(comment.Data.DateLastEdited).NotTo(BeNil())
+			Expect(comment.Data.DateLastEdited.Before(comment.Date)).To(BeFalse())
+
+			fetched, err := client.Action(comment.ID)
+			Expect(err).To(BeNil())
+			Expect(fetched.Data.Text).To(Equal("Status: done"))
+			Expect(fetched.EditComment("")).NotTo(BeNil())
+		})
+
+		g.It("should react to a comment", func() {
+			reaction, err := comment.AddReaction("thumbsup")
+			Expect(err).To(BeNil())
+			Expect(reaction.IDModel).To(Equal(comment.ID))
+			Expect(reaction.Emoji.ShortName).To(Equal("thumbsup"))
+			_, err = comment.AddReaction("thumbsup")
+			Expect(err).NotTo(BeNil())
+
+			reactions, err := comment.Reactions()
+			Expect(err).To(BeNil())
+			Expect(len(reactions)).To(Equal(1))
+			Expect(reactions[0].Emoji.Unified).To(Equal("1F44D"))
+			Expect(reactions[0].Member.ID).To(Equal(reaction.IDMember))
+
+			Expect(reactions[0].Delete()).To(BeNil())
+			reactions, err = comment.Reactions()
+			Expect(err).To(BeNil())
+			Expect(reactions).To(BeEmpty())
+		})
+
+		g.It("should delete a comment", func() {
+			Expect(comment.Delete()).To(BeNil())
+			comments, err := card.Comments()
+			Expect(err).To(BeNil())
+			Expect(len(comments)).To(Equal(1))
+			Expect(comments[0].Data.Text).To(Equal("First"))
+		})
+
+		g.It("should only delete comments", func() {
+			actions, err := card.Actions(NewArgument("filter", string(CreateCard)))
+			Expect(err).To(BeNil())
+			Expect(len(actions)).To(Equal(1))
+			Expect(actions[0].Delete()).NotTo(BeNil())
+		})
+
+		g.After(func() {
+			err = board.Delete()
+			Expect(err).To(BeNil())
+		})
+	})
+}
@@ -16,19 +16,28 @@ limitations under the License.
 
 package trello
 
-import "encoding/json"
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
 
 // Attachment - Type
 type Attachment struct {
 	client    *Client
-	ID        string `json:"id"`
-	Bytes     int    `json:"bytes"`
-	Date      string `json:"date"`
-	EdgeColor string `json:"edgeColor"`
-	IDMember  string `json:"idMember"`
-	IsUpload  bool   `json:"isUpload"`
-	MimeType  string `json:"mimeType"`
-	Name      string `json:"name"`
+	ID        string    `json:"id"`
+	Bytes     int       `json:"bytes"`
+	Date      time.Time `json:"date"`
+	EdgeColor string    `json:"edgeColor"`
+	IDMember  string    `json:"idMember"`
+	IsUpload  bool      `json:"isUpload"`
+	MimeType  string    `json:"mimeType"`
+	Name      string    `json:"name"`
 	Previews  []struct {
 		Width  int    `json:"width"`
 		Height int    `json:"height"`
@@ -38,6 +47,57 @@ type Attachment struct {
 		Scaled bool   `json:"scaled"`
 	} `json:"previews"`
 	URL string `json:"url"`
+
+	// cardID - Card the attachment is on (Trello does not include it)
+	cardID string
+}
+
+// Delete - Remove an Attachment from its Card
+// - https://developer.atlassian.com/cloud/trello/rest/api-group-cards/#api-cards-id-attachments-idattachment-delete
+func (a *Attachment) Delete() (err error) {
+	return a.DeleteContext(context.Background())
+}
+
+// DeleteContext - Remove an Attachment from its Card (with context)
+// The attachment must come from the methods of its Card, as Trello does not say which card it is on.
+func (a *Attachment) DeleteContext(ctx context.Context) (err error) {
+	if a.cardID == "" {
+		return fmt.Errorf("Attachment %s has no card; get it with Card.Attachment", a.ID)
+	}
+	_, err = a.client.DeleteContext(ctx, "/cards/"+a.cardID+"/attachments/"+a.ID)
+	return
+}
+
+// Download - Stream an uploaded file to w, returning the number of bytes written
+// Trello only serves uploads to requests authenticated with an OAuth Authorization
+// header, which is sent to the client endpoint only. Links (IsUpload false) cannot be downloaded.
+// - https://developer.atlassian.com/cloud/trello/guides/rest-api/authorization/
+func (a *Attachment) Download(ctx context.Context, w io.Writer) (written int64, err error) {
+	if !a.IsUpload {
+		return 0, fmt.Errorf("Attachment %s is a link, not an uploaded file", a.ID)
+	}
+	u, err := url.Parse(a.URL)
+	if err != nil {
+		return
+	}
+	path := u.EscapedPath()
+	i := strings.Index(path, "/cards/")
+	if i < 0 {
+		return 0, fmt.Errorf("Unexpected download URL for attachment %s: %s", a.ID, a.URL)
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", a.client.endpoint+path[i:], nil)
+	if err != nil {
+		return
+	}
+	if a.client.token != nil {
+		req.Header.Set("Authorization", fmt.Sprintf(`OAuth oauth_consumer_key="%s", oauth_token="%s"`, a.client.key, *a.client.token))
+	}
+	resp, err := a.client.doStream(req)
+	if err == nil {
+		defer resp.Body.Close()
+		written, err = io.Copy(w, resp.Body)
+	}
+	return
 }
 
 func parseAttachment(body []byte, attachment *Attachment, client *Client) (err error) {
@@ -55,3 +115,9 @@ func parseListAttachments(body []byte, client *Client) (attachments []Attachment
 	}
 	return
 }
+
+func setAttachmentsCard(attachments []Attachment, cardID string) {
+	for i := range attachments {
+		attachments[i].cardID = cardID
+	}
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trello
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	goblin "github.com/franela/goblin"
+	. "github.com/onsi/gomega"
+)
+
+// pixel - A 1x1 transparent PNG
+var pixel = []byte{
+	0x89, 0x50, 0x4e, 0x47, 0x0d, 0x0a, 0x1a, 0x0a, 0x00, 0x00, 0x00, 0x0d,
+	0x49, 0x48, 0x44, 0x52, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x01,
+	0x08, 0x06, 0x00, 0x00, 0x00, 0x1f, 0x15, 0xc4, 0x89, 0x00, 0x00, 0x00,
+	0x0d, 0x49, 0x44, 0x41, 0x54, 0x78, 0x9c, 0x63, 0x60, 0x00, 0x02, 0x00,
+	0x00, 0x05, 0x00, 0x01, 0xe9, 0xfa, 0xdc, 0xd8, 0x00, 0x00, 0x00, 0x00,
+	0x49, 0x45, 0x4e, 0x44, 0xae, 0x42, 0x60, 0x82,
+}
+
+func TestAttachments(t *testing.T) {
+	g := goblin.Goblin(t)
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+
+	g.Describe("Attachment tests", func() {
+		var board *Board
+		var card *Card
+		var image *Attachment
+		var link *Attachment
+
+		g.Before(func() {
+			board, err = client.CreateBoard(testName("GoTestTrello-Attachments"))
+			Expect(err).To(BeNil())
+			lists, err := board.Lists()
+			Expect(err).To(BeNil())
+			card, err = lists[0].AddCard(Card{Name: "Attached"})
+			Expect(err).To(BeNil())
+		})
+
+		g.It("should upload a file", func() {
+			image, err = card.AttachFile("pixel.png", bytes.NewReader(pixel), "image/png")
+			Expect(err).To(BeNil())
+			Expect(image.IsUpload).To(BeTrue())
+			Expect(image.Name).To(Equal("pixel.png"))
+			Expect(image.MimeType).To(Equal("image/png"))
+			Expect(image.Bytes).To(Equal(len(pixel)))
+		})
+
+		g.It("should attach a link", func() {
+			link, err = card.AttachURL("https://example.com/spec.pdf", "Spec")
+			Expect(err).To(BeNil())
+			Expect(link.IsUpload).To(BeFalse())
+			Expect(link.Name).To(Equal("Spec"))
+			Expect(link.URL).To(Equal("https://example.com/spec.pdf"))
+		})
+
+		g.It("should list the attachments of a card", func() {
+			Expect(image).NotTo(BeNil())
+			Expect(link).NotTo(BeNil())
+			attachments, err := card.Attachments()
+			Expect(err).To(BeNil())
+			Expect(len(attachments)).To(Equal(2))
+			Expect(attachments[0].ID).To(Equal(image.ID))
+			Expect(attachments[1].ID).To(Equal(link.ID))
+
+			fetched, err := client.Card(card.ID, IncludeAttachments())
+			Expect(err).To(BeNil())
+			attachments, err = fetched.Attachments()
+			Expect(err).To(BeNil())
+			Expect(len(attachments)).To(Equal(2))
+		})
+
+		g.It("should download an uploaded file", func() {
+			Expect(image).NotTo(BeNil())
+			Expect(link).NotTo(BeNil())
+			attachment, err := card.Attachment(image.ID)
+			Expect(err).To(BeNil())
+			var buf bytes.Buffer
+			written, err := attachment.Download(context.Background(), &buf)
+			Expect(err).To(BeNil())
+			Expect(written).To(Equal(int64(len(pixel))))
+			Expect(buf.Bytes()).To(Equal(pixel))
+
+			_, err = link.Download(context.Background(), &buf)
+			Expect(err).NotTo(BeNil())
+		})
+
+		g.It("should set and remove the cover", func() {
+			Expect(image).NotTo(BeNil())
+			Expect(link).NotTo(BeNil())
+			Expect(card.SetCover(image)).To(BeNil())
+			Expect(card.IDAttachmentCover).To(Equal(image.ID))
+			Expect(card.SetCover(link)).NotTo(BeNil())
+			Expect(card.SetCover(nil)).To(BeNil())
+			Expect(card.IDAttachmentCover).To(BeEmpty())
+		})
+
+		g.It("should delete attachments", func() {
+			Expect(image).NotTo(BeNil())
+			Expect(link).NotTo(BeNil())
+			// Decoded elsewhere, the attachment does not know its card
+			var decoded []Attachment
+			request := NewBatchRequest("/cards/"+card.ID+"/attachments", &decoded)
+			Expect(client.Batch(request)).To(BeNil())
+			Expect(request.Err).To(BeNil())
+			Expect(len(decoded)).To(Equal(2))
+			Expect(decoded[0].Delete()).NotTo(BeNil())
+
+			Expect(image.Delete()).To(BeNil())
+			Expect(link.Delete()).To(BeNil())
+			attachments, err := card.Attachments()
+			Expect(err).To(BeNil())
+			Expect(attachments).To(BeEmpty())
+		})
+
+		g.After(func() {
+			err = board.Delete()
+			Expect(err).To(BeNil())
+		})
+	})
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trello
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// MaxBatchSize - Most routes Trello accepts in a single batch request
+const MaxBatchSize = 10
+
+// BatchRequest - A GET route fetched as part of Client.Batch
+// After the batch completes the decoded response is in the value given to the
+// constructor, or Err holds the error for this route alone.
+type BatchRequest struct {
+	Resource string
+	Err      error
+
+	decode func(body []byte, client *Client) error
+}
+
+// NewBatchRequest - Fetch resource (e.g. "/cards/{id}/checklists") in a batch, decoding the JSON response into v
+func NewBatchRequest(resource string, v interface{}) *BatchRequest {
+	return &BatchRequest{
+		Resource: resource,
+		decode: func(body []byte, _ *Client) error {
+			return json.Unmarshal(body, v)
+		},
+	}
+}
+
+// BatchBoard - Fetch a board by ID in a batch
+func BatchBoard(boardID string, board *Board) *BatchRequest {
+	return &BatchRequest{
+		Resource: "/boards/" + boardID,
+		decode:   board.parseBoard,
+	}
+}
+
+// BatchList - Fetch a list by ID in a batch
+func BatchList(listID string, list *List) *BatchRequest {
+	return &BatchRequest{
+		Resource: "/lists/" + listID,
+		decode: func(body []byte, client *Client) error {
+			return parseList(body, list, client)
+		},
+	}
+}
+
+// BatchCard - Fetch a card by ID in a batch
+func BatchCard(cardID string, card *Card) *BatchRequest {
+	return &BatchRequest{
+		Resource: "/cards/" + cardID,
+		decode: func(body []byte, client *Client) error {
+			return parseCard(body, card, client)
+		},
+	}
+}
+
+// BatchChecklist - Fetch a checklist by ID in a batch
+func BatchChecklist(checklistID string, checklist *Checklist) *BatchRequest {
+	return &BatchRequest{
+		Resource: "/checklists/" + checklistID,
+		decode: func(body []byte, client *Client) error {
+			return parseChecklist(body, checklist, client)
+		},
+	}
+}
+
+// BatchMember - Fetch a member by ID or username in a batch
+func BatchMember(nick string, member *Member) *BatchRequest {
+	return &BatchRequest{
+		Resource: "/members/" + nick,
+		decode: func(body []byte, client *Client) error {
+			return parseMember(body, member, client)
+		},
+	}
+}
+
+// BatchOrganization - Fetch an organization by ID or name in a batch
+func BatchOrganization(orgID string, organization *Organization) *BatchRequest {
+	return &BatchRequest{
+		Resource: "/organizations/" + orgID,
+		decode: func(body []byte, client *Client) error {
+			return parseOrganization(body, organization, client)
+		},
+	}
+}
+
+// Batch - Fetch many GET routes using as few requests as possible
+// Requests are sent in groups of MaxBatchSize, concurrently. The returned error
+// reports batches that failed as a whole; check each BatchRequest.Err for the
+// result of an individual route.
+// - https://developer.atlassian.com/cloud/trello/rest/api-group-batch/#api-batch-get
+func (c *Client) Batch(requests ...*BatchRequest) error {
+	return c.BatchContext(context.Background(), requests...)
+}
+
+// BatchContext - Fetch many GET routes using as few requests as possible (with context)
+// Routes are separated by commas in the batch request, so a resource containing one
+// (such as "fields=name,desc") is not sent and gets an error of its own.
+func (c *Client) BatchContext(ctx context.Context, requests ...*BatchRequest) error {
+	valid := make([]*BatchRequest, 0, len(requests))
+	for _, r := range requests {
+		if strings.Contains(r.Resource, ",") {
+			r.Err = fmt.Errorf("Batch resource %s contains a comma", r.Resource)
+			continue
+		}
+		valid = append(valid, r)
+	}
+	requests = valid
+
+	var wg sync.WaitGroup
+	errs := make([]error, (len(requests)+MaxBatchSize-1)/MaxBatchSize)
+	for i := range errs {
+		end := (i + 1) * MaxBatchSize
+		if end > len(requests) {
+			end = len(requests)
+		}
+		wg.Add(1)
+		go func(i int, chunk []*BatchRequest) {
+			defer wg.Done()
+			errs[i] = c.batch(ctx, chunk)
+		}(i, requests[i*MaxBatchSize:end])
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// batch - Send a single batch request of at most MaxBatchSize routes
+func (c *Client) batch(ctx context.Context, requests []*BatchRequest) (err error) {
+	urls := make([]string, len(requests))
+	for i, r := range requests {
+		urls[i] = r.Resource
+	}
+	query := url.Values{}
+	query.Set("urls", strings.Join(urls, ","))
+
+	body, err := c.GetContext(ctx, "/batch?"+query.Encode())
+	var responses []map[string]json.RawMessage
+	if err == nil {
+		err = json.Unmarshal(body, &responses)
+	}
+	if err == nil && len(responses) != len(requests) {
+		err = fmt.Errorf("Batch returned %d responses for %d requests", len(responses), len(requests))
+	}
+	if err != nil {
+		for _, r := range requests {
+			r.Err = err
+		}
+		return
+	}
+	for i, r := range requests {
+		r.Err = r.result(responses[i], c)
+	}
+	return
+}
+
+// result - Decode one entry of a batch response, which is either {"200": <body>},
+// {"<status>": <message>} or an error object with a statusCode
+func (r *BatchRequest) result(response map[string]json.RawMessage, client *Client) error {
+	if body, ok := response["200"]; ok {
+		return r.decode(body, client)
+	}
+	apiErr := &APIError{
+		StatusCode: http.StatusInternalServerError,
+		Method:     http.MethodGet,
+		Resource:   r.Resource,
+	}
+	for key, value := range response {
+		if status, err := strconv.Atoi(key); err == nil {
+			apiErr.StatusCode = status
+			apiErr.Body = rawMessage(value)
+			return apiErr
+		}
+	}
+	if value, ok := response["statusCode"]; ok {
+		json.Unmarshal(value, &apiErr.StatusCode)
+	}
+	apiErr.Body = rawMessage(response["message"])
+	return apiErr
+}
+
+// rawMessage - The text of a JSON string, or the raw JSON of any other value
+func rawMessage(value json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(value, &s); err == nil {
+		return s
+	}
+	return string(value)
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trello
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	goblin "github.com/franela/goblin"
+	. "github.com/onsi/gomega"
+)
+
+func TestBatch(t *testing.T) {
+	g := goblin.Goblin(t)
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+
+	g.Describe("Batch tests", func() {
+		var board *Board
+		var lists []List
+		var cards []Card
+
+		g.Before(func() {
+			board, err = client.CreateBoard(testName("GoTestTrello-Batch"))
+			Expect(err).To(BeNil())
+			lists, err = board.Lists()
+			Expect(err).To(BeNil())
+			for i := 0; i < 12; i++ {
+				card, err := lists[0].AddCard(Card{Name: fmt.Sprintf("Batch card %d", i)})
+				Expect(err).To(BeNil())
+				cards = append(cards, *card)
+			}
+		})
+
+		g.It("should fetch more cards than fit in a single batch", func() {
+			fetched := make([]Card, len(cards))
+			var requests []*BatchRequest
+			for i := range cards {
+				requests = append(requests, BatchCard(cards[i].ID, &fetched[i]))
+			}
+			err := client.Batch(requests...)
+			Expect(err).To(BeNil())
+			for i := range cards {
+				Expect(requests[i].Err).To(BeNil())
+				Expect(fetched[i].ID).To(Equal(cards[i].ID))
+				Expect(fetched[i].Name).To(Equal(cards[i].Name))
+				Expect(fetched[i].client).NotTo(BeNil())
+			}
+		})
+
+		g.It("should fetch different types of resources in one batch", func() {
+			var b Board
+			var l List
+			var checklists []Checklist
+			requests := []*BatchRequest{
+				BatchBoard(board.ID, &b),
+				BatchList(lists[1].ID, &l),
+				NewBatchRequest("/cards/"+cards[0].ID+"/checklists", &checklists),
+			}
+			err := client.Batch(requests...)
+			Expect(err).To(BeNil())
+			for _, r := range requests {
+				Expect(r.Err).To(BeNil())
+			}
+			Expect(b.Name).To(Equal(board.Name))
+			Expect(l.Name).To(Equal(lists[1].Name))
+			Expect(l.client).NotTo(BeNil())
+			Expect(checklists).To(BeEmpty())
+		})
+
+		g.It("should report errors for individual routes", func() {
+			var good, missing, invalid Card
+			requests := []*BatchRequest{
+				BatchCard(cards[0].ID, &good),
+				BatchCard("5f0000000000000000000000", &missing),
+				BatchCard("not-an-id", &invalid),
+			}
+			err := client.Batch(requests...)
+			Expect(err).To(BeNil())
+			Expect(requests[0].Err).To(BeNil())
+			Expect(good.ID).To(Equal(cards[0].ID))
+			Expect(errors.Is(requests[1].Err, ErrNotFound)).To(BeTrue())
+			var apiErr *APIError
+			Expect(errors.As(requests[2].Err, &apiErr)).To(BeTrue())
+			Expect(apiErr.StatusCode).To(Equal(400))
+			Expect(apiErr.Resource).To(Equal("/cards/not-an-id"))
+		})
+
+		g.It("should reject resources containing a comma", func() {
+			var good, fields Card
+			requests := []*BatchRequest{
+				NewBatchRequest("/cards/"+cards[0].ID+"?fields=name,desc", &fields),
+				BatchCard(cards[1].ID, &good),
+			}
+			Expect(client.Batch(requests...)).To(BeNil())
+			Expect(requests[0].Err).NotTo(BeNil())
+			Expect(fields.ID).To(BeEmpty())
+			Expect(requests[1].Err).To(BeNil())
+			Expect(good.ID).To(Equal(cards[1].ID))
+		})
+
+		g.It("should do nothing for an empty batch", func() {
+			Expect(client.Batch()).To(BeNil())
+		})
+
+		g.After(func() {
+			err = board.Delete()
+			Expect(err).To(BeNil())
+		})
+	})
+}
@@ -17,8 +17,10 @@ limitations under the License.
 package trello
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"net/url"
 	"strconv"
 )
@@ -68,6 +70,13 @@ type Board struct {
 		Blue   string `json:"blue"`
 		Purple string `json:"purple"`
 	} `json:"labelNames"`
+
+	// Nested resources, set when the board is fetched with IncludeLists, IncludeCards...
+	lists      []List
+	cards      []Card
+	checklists []Checklist
+	labels     []Label
+	actions    []Action
 }
 
 // BoardBackground Type
@@ -78,12 +87,19 @@ type BoardBackground struct {
 }
 
 // Board - Get board by boardID
+// Nested resources requested with opts (IncludeLists, IncludeCards...) are returned
+// by the corresponding Board methods without another request.
 // - https://developer.atlassian.com/cloud/trello/rest/api-group-boards/#api-boards-id-get
-func (c *Client) Board(boardID string) (board *Board, err error) {
-	body, err := c.Get("/boards/" + boardID)
+func (c *Client) Board(boardID string, opts ...QueryOption) (board *Board, err error) {
+	return c.BoardContext(context.Background(), boardID, opts...)
+}
+
+// BoardContext - Get board by boardID (with context)
+func (c *Client) BoardContext(ctx context.Context, boardID string, opts ...QueryOption) (board *Board, err error) {
+	body, err := c.GetContext(ctx, encodeQuery("/boards/"+boardID, opts))
 	if err == nil {
 		board = &Board{}
-		board.parseBoard(body, c)
+		err = board.parseBoard(body, c)
 	}
 	return
 }
@@ -91,10 +107,15 @@ func (c *Client) Board(boardID string) (board *Board, err error) {
 // CreateBoard - Create Board
 // - https://developer.atlassian.com/cloud/trello/rest/api-group-boards/#api-boards-post
 func (c *Client) CreateBoard(name string) (board *Board, err error) {
+	return c.CreateBoardContext(context.Background(), name)
+}
+
+// CreateBoardContext - Create Board (with context)
+func (c *Client) CreateBoardContext(ctx context.Context, name string) (board *Board, err error) {
 	payload := url.Values{}
 	payload.Set("name", name)
 
-	body, err := c.Post("/boards", payload)
+	body, err := c.PostContext(ctx, "/boards", payload)
 	if err == nil {
 		board = &Board{}
 		err = board.parseBoard(body, c)
@@ -105,6 +126,11 @@ func (c *Client) CreateBoard(name string) (board *Board, err error) {
 // Duplicate - Duplicate (Copy) Board
 // - https://developer.atlassian.com/cloud/trello/rest/api-group-boards/#api-boards-post
 func (b *Board) Duplicate(name string, keepCards bool) (board *Board, err error) {
+	return b.DuplicateContext(context.Background(), name, keepCards)
+}
+
+// DuplicateContext - Duplicate (Copy) Board (with context)
+func (b *Board) DuplicateContext(ctx context.Context, name string, keepCards bool) (board *Board, err error) {
 	keepFromSource := "none"
 	if keepCards {
 		keepFromSource = "cards"
@@ -114,7 +140,7 @@ func (b *Board) Duplicate(name string, keepCards bool) (board *Board, err error)
 	payload.Set("keepFromSource", keepFromSource)
 	payload.Set("name", name)
 
-	body, err := b.client.Post("/boards", payload)
+	body, err := b.client.PostContext(ctx, "/boards", payload)
 	if err == nil {
 		board = &Board{}
 		err = board.parseBoard(body, b.client)
@@ -125,21 +151,36 @@ func (b *Board) Duplicate(name string, keepCards bool) (board *Board, err error)
 // SetBackground - Sets background on board
 // background can be a color or a background id
 func (b *Board) SetBackground(background string) (err error) {
-	return b.Update("prefs/background", background)
+	return b.SetBackgroundContext(context.Background(), background)
+}
+
+// SetBackgroundContext - Sets background on board (with context)
+func (b *Board) SetBackgroundContext(ctx context.Context, background string) (err error) {
+	return b.UpdateContext(ctx, "prefs/background", background)
 }
 
 // SetDescription - Sets background on board
 func (b *Board) SetDescription(description string) (err error) {
-	return b.Update("desc", description)
+	return b.SetDescriptionContext(context.Background(), description)
+}
+
+// SetDescriptionContext - Sets description on board (with context)
+func (b *Board) SetDescriptionContext(ctx context.Context, description string) (err error) {
+	return b.UpdateContext(ctx, "desc", description)
 }
 
 // Update - Update a Board (path and value, see API docs for details)
 // - https://developer.atlassian.com/cloud/trello/rest/api-group-boards/#api-boards-id-put
 func (b *Board) Update(path, value string) (err error) {
+	return b.UpdateContext(context.Background(), path, value)
+}
+
+// UpdateContext - Update a Board (with context)
+func (b *Board) UpdateContext(ctx context.Context, path, value string) (err error) {
 	payload := url.Values{}
 	payload.Set("value", value)
 
-	body, err := b.client.Put("/boards/"+b.ID+"/"+path, payload)
+	body, err := b.client.PutContext(ctx, "/boards/"+b.ID+"/"+path, payload)
 	if err == nil {
 		err = b.parseBoard(body, b.client)
 	}
@@ -150,14 +191,28 @@ func (b *Board) Update(path, value string) (err error) {
 //  *WARNING* - No Confirmation Dialog!
 // - https://developer.atlassian.com/cloud/trello/rest/api-group-boards/#api-boards-id-delete
 func (b *Board) Delete() (err error) {
-	_, err = b.client.Delete("/boards/" + b.ID)
+	return b.DeleteContext(context.Background())
+}
+
+// DeleteContext - Delete a Board (with context)
+func (b *Board) DeleteContext(ctx context.Context) (err error) {
+	_, err = b.client.DeleteContext(ctx, "/boards/"+b.ID)
 	return
 }
 
 // Lists - Get lists on a board
+// Without opts the lists loaded with IncludeLists are returned, if any.
 // - https://developer.atlassian.com/cloud/trello/rest/api-group-boards/#api-boards-id-lists-get
-func (b *Board) Lists() (lists []List, err error) {
-	body, err := b.client.Get("/boards/" + b.ID + "/lists")
+func (b *Board) Lists(opts ...QueryOption) (lists []List, err error) {
+	return b.ListsContext(context.Background(), opts...)
+}
+
+// ListsContext - Get lists on a board (with context)
+func (b *Board) ListsContext(ctx context.Context, opts ...QueryOption) (lists []List, err error) {
+	if b.lists != nil && len(opts) == 0 {
+		return b.lists, nil
+	}
+	body, err := b.client.GetContext(ctx, encodeQuery("/boards/"+b.ID+"/lists", opts))
 	if err == nil {
 		lists, err = parseListLists(body, b.client)
 	}
@@ -167,11 +222,16 @@ func (b *Board) Lists() (lists []List, err error) {
 // GetMembers - Get the members of a board
 // - https://developer.atlassian.com/cloud/trello/rest/api-group-boards/#api-boards-id-members-get
 func (b *Board) GetMembers() (members []*Member, err error) {
+	return b.GetMembersContext(context.Background())
+}
+
+// GetMembersContext - Get the members of a board (with context)
+func (b *Board) GetMembersContext(ctx context.Context) (members []*Member, err error) {
 	if len(b.Members) == 0 {
-		body, err := b.client.Get("/boards/" + b.ID + "/members")
+		var body []byte
+		body, err = b.client.GetContext(ctx, "/boards/"+b.ID+"/members")
 		if err == nil {
 			members, err = parseListMembers(body, b.client)
-
 		}
 	} else {
 		members = b.Members
@@ -183,12 +243,17 @@ func (b *Board) GetMembers() (members []*Member, err error) {
 // memberType can be admin, normal or observer (if left blank will default to normal)
 // - https://developer.atlassian.com/cloud/trello/rest/api-group-boards/#api-boards-id-members-idmember-put
 func (b *Board) AddMember(member *Member, memberType string) (err error) {
+	return b.AddMemberContext(context.Background(), member, memberType)
+}
+
+// AddMemberContext - Add a Member to a board (with context)
+func (b *Board) AddMemberContext(ctx context.Context, member *Member, memberType string) (err error) {
 	if memberType == "" {
 		memberType = "normal" // default to "normal"
 	}
 	payload := url.Values{}
 	payload.Set("type", memberType)
-	body, err := b.client.Put("/boards/"+b.ID+"/members/"+member.ID, payload)
+	body, err := b.client.PutContext(ctx, "/boards/"+b.ID+"/members/"+member.ID, payload)
 	if err == nil {
 		err = b.parseBoard(body, b.client)
 	}
@@ -198,7 +263,12 @@ func (b *Board) AddMember(member *Member, memberType string) (err error) {
 // RemoveMember - Remove a Member from a board
 // - https://developer.atlassian.com/cloud/trello/rest/api-group-boards/#api-boards-id-members-idmember-put
 func (b *Board) RemoveMember(member *Member) (err error) {
-	body, err := b.client.Delete("/boards/" + b.ID + "/members/" + member.ID)
+	return b.RemoveMemberContext(context.Background(), member)
+}
+
+// RemoveMemberContext - Remove a Member from a board (with context)
+func (b *Board) RemoveMemberContext(ctx context.Context, member *Member) (err error) {
+	body, err := b.client.DeleteContext(ctx, "/boards/"+b.ID+"/members/"+member.ID)
 	if err == nil {
 		err = b.parseBoard(body, b.client)
 	}
@@ -210,7 +280,12 @@ func (b *Board) RemoveMember(member *Member) (err error) {
 // filter: Valid Values: admins, all, none, normal (default: all)
 // - https://developer.atlassian.com/cloud/trello/rest/api-group-boards/#api-boards-id-memberships-get
 func (b *Board) GetMembership(id string) (membership *Membership, err error) {
-	body, err := b.client.Get("/boards/" + b.ID + "/memberships/" + id)
+	return b.GetMembershipContext(context.Background(), id)
+}
+
+// GetMembershipContext - Get a Membership of a board by ID (with context)
+func (b *Board) GetMembershipContext(ctx context.Context, id string) (membership *Membership, err error) {
+	body, err := b.client.GetContext(ctx, "/boards/"+b.ID+"/memberships/"+id)
 	if err == nil {
 		membership = &Membership{}
 		err = parseMembership(body, membership, b)
@@ -223,8 +298,14 @@ func (b *Board) GetMembership(id string) (membership *Membership, err error) {
 // filter: Valid Values: admins, all, none, normal (default: all)
 // - https://developer.atlassian.com/cloud/trello/rest/api-group-boards/#api-boards-id-memberships-get
 func (b *Board) GetMemberships() (memberships []*Membership, err error) {
+	return b.GetMembershipsContext(context.Background())
+}
+
+// GetMembershipsContext - Get Memberships of a board (with context)
+func (b *Board) GetMembershipsContext(ctx context.Context) (memberships []*Membership, err error) {
 	if len(b.Memberships) == 0 {
-		body, err := b.client.Get("/boards/" + b.ID + "/memberships")
+		var body []byte
+		body, err = b.client.GetContext(ctx, "/boards/"+b.ID+"/memberships")
 		if err == nil {
 			memberships, err = parseListMemberships(body, b)
 			if err == nil {
@@ -239,7 +320,12 @@ func (b *Board) GetMemberships() (memberships []*Membership, err error) {
 
 // GetMembershipForMember - Return a Membership that matches a specific Member
 func (b *Board) GetMembershipForMember(member *Member) (membership *Membership, err error) {
-	memberships, err := b.GetMemberships()
+	return b.GetMembershipForMemberContext(context.Background(), member)
+}
+
+// GetMembershipForMemberContext - Return a Membership that matches a specific Member (with context)
+func (b *Board) GetMembershipForMemberContext(ctx context.Context, member *Member) (membership *Membership, err error) {
+	memberships, err := b.GetMembershipsContext(ctx)
 	if err == nil {
 		for _, ms := range memberships {
 			if ms.IDMember == member.ID {
@@ -255,7 +341,12 @@ func (b *Board) GetMembershipForMember(member *Member) (membership *Membership,
 
 // IsAdmin - Check to see if a member is an admin
 func (b *Board) IsAdmin(member *Member) (isAdmin bool) {
-	ms, err := b.GetMembershipForMember(member)
+	return b.IsAdminContext(context.Background(), member)
+}
+
+// IsAdminContext - Check to see if a member is an admin (with context)
+func (b *Board) IsAdminContext(ctx context.Context, member *Member) (isAdmin bool) {
+	ms, err := b.GetMembershipForMemberContext(ctx, member)
 	if err == nil {
 		return ms.MemberType == "admin"
 	}
@@ -263,9 +354,18 @@ func (b *Board) IsAdmin(member *Member) (isAdmin bool) {
 }
 
 // Cards - Get cards on a board
+// Without opts the cards loaded with IncludeCards are returned, if any.
 // - https://developer.atlassian.com/cloud/trello/rest/api-group-boards/#api-boards-id-cards-get
-func (b *Board) Cards() (cards []Card, err error) {
-	body, err := b.client.Get("/boards/" + b.ID + "/cards")
+func (b *Board) Cards(opts ...QueryOption) (cards []Card, err error) {
+	return b.CardsContext(context.Background(), opts...)
+}
+
+// CardsContext - Get cards on a board (with context)
+func (b *Board) CardsContext(ctx context.Context, opts ...QueryOption) (cards []Card, err error) {
+	if b.cards != nil && len(opts) == 0 {
+		return b.cards, nil
+	}
+	body, err := b.client.GetContext(ctx, encodeQuery("/boards/"+b.ID+"/cards", opts))
 	if err == nil {
 		cards, err = parseListCards(body, b.client)
 	}
@@ -274,9 +374,14 @@ func (b *Board) Cards() (cards []Card, err error) {
 
 // Card - Get a card on a board
 // - https://developer.atlassian.com/cloud/trello/rest/api-group-boards/#api-boards-id-cards-idcard-get
-func (b *Board) Card(IDCard string) (card *Card, err error) {
+func (b *Board) Card(IDCard string, opts ...QueryOption) (card *Card, err error) {
+	return b.CardContext(context.Background(), IDCard, opts...)
+}
+
+// CardContext - Get a card on a board (with context)
+func (b *Board) CardContext(ctx context.Context, IDCard string, opts ...QueryOption) (card *Card, err error) {
 	card = &Card{}
-	body, err := b.client.Get("/boards/" + b.ID + "/cards/" + IDCard)
+	body, err := b.client.GetContext(ctx, encodeQuery("/boards/"+b.ID+"/cards/"+IDCard, opts))
 	if err == nil {
 		err = parseCard(body, card, b.client)
 	}
@@ -284,9 +389,18 @@ func (b *Board) Card(IDCard string) (card *Card, err error) {
 }
 
 // Checklists - Get checklists on a board
+// Without opts the checklists loaded with IncludeChecklists are returned, if any.
 // - https://developer.atlassian.com/cloud/trello/rest/api-group-boards/#api-boards-id-checklists-get
-func (b *Board) Checklists() (checklists []Checklist, err error) {
-	body, err := b.client.Get("/boards/" + b.ID + "/checklists")
+func (b *Board) Checklists(opts ...QueryOption) (checklists []Checklist, err error) {
+	return b.ChecklistsContext(context.Background(), opts...)
+}
+
+// ChecklistsContext - Get checklists on a board (with context)
+func (b *Board) ChecklistsContext(ctx context.Context, opts ...QueryOption) (checklists []Checklist, err error) {
+	if b.checklists != nil && len(opts) == 0 {
+		return b.checklists, nil
+	}
+	body, err := b.client.GetContext(ctx, encodeQuery("/boards/"+b.ID+"/checklists", opts))
 	if err == nil {
 		checklists, err = parseListChecklists(body, b.client)
 	}
@@ -296,7 +410,12 @@ func (b *Board) Checklists() (checklists []Checklist, err error) {
 // MemberCards - Get cards for a member ID (string) on a board?
 // - URL Link?
 func (b *Board) MemberCards(IDMember string) (cards []Card, err error) {
-	body, err := b.client.Get("/boards/" + b.ID + "/members/" + IDMember + "/cards")
+	return b.MemberCardsContext(context.Background(), IDMember)
+}
+
+// MemberCardsContext - Get cards for a member ID (string) on a board (with context)
+func (b *Board) MemberCardsContext(ctx context.Context, IDMember string) (cards []Card, err error) {
+	body, err := b.client.GetContext(ctx, "/boards/"+b.ID+"/members/"+IDMember+"/cards")
 	if err == nil {
 		cards, err = parseListCards(body, b.client)
 	}
@@ -304,22 +423,37 @@ func (b *Board) MemberCards(IDMember string) (cards []Card, err error) {
 }
 
 // Actions - Get Actions for a Board
-// - URL LINK?
-func (b *Board) Actions(arg ...*Argument) (actions []Action, err error) {
-	ep := "/boards/" + b.ID + "/actions"
-	if query := EncodeArgs(arg); query != "" {
-		ep += "?" + query
-	}
+// Without opts the actions loaded with IncludeActions are returned, if any.
+// - https://developer.atlassian.com/cloud/trello/rest/api-group-boards/#api-boards-boardid-actions-get
+func (b *Board) Actions(opts ...QueryOption) (actions []Action, err error) {
+	return b.ActionsContext(context.Background(), opts...)
+}
 
-	body, err := b.client.Get(ep)
+// ActionsContext - Get Actions for a Board (with context)
+func (b *Board) ActionsContext(ctx context.Context, opts ...QueryOption) (actions []Action, err error) {
+	if b.actions != nil && len(opts) == 0 {
+		return b.actions, nil
+	}
+	body, err := b.client.GetContext(ctx, encodeQuery("/boards/"+b.ID+"/actions", opts))
 	if err == nil {
 		actions, err = parseListActions(body, b.client)
 	}
 	return
 }
 
+// ActionsIter - Iterate over all Actions for a Board, newest first, fetching pages as needed
+// - https://developer.atlassian.com/cloud/trello/rest/api-group-boards/#api-boards-boardid-actions-get
+func (b *Board) ActionsIter(ctx context.Context, filter ActionFilter) iter.Seq2[Action, error] {
+	return b.client.actionsIter(ctx, "/boards/"+b.ID+"/actions", filter)
+}
+
 // AddList - Add a List to a Board
 func (b *Board) AddList(opts List) (list *List, err error) {
+	return b.AddListContext(context.Background(), opts)
+}
+
+// AddListContext - Add a List to a Board (with context)
+func (b *Board) AddListContext(ctx context.Context, opts List) (list *List, err error) {
 	list = &List{}
 	opts.IDBoard = b.ID
 
@@ -328,17 +462,27 @@ func (b *Board) AddList(opts List) (list *List, err error) {
 	payload.Set("idBoard", opts.IDBoard)
 	payload.Set("pos", strconv.FormatFloat(float64(opts.Pos), 'g', -1, 32))
 
-	body, err := b.client.Post("/lists", payload)
+	body, err := b.client.PostContext(ctx, "/lists", payload)
 	if err == nil {
+		b.lists = nil
 		err = parseList(body, list, b.client)
 	}
 	return
 }
 
 // Labels - Get Labels on a Board
+// Without opts the labels loaded with IncludeLabels are returned, if any.
 // - https://developer.atlassian.com/cloud/trello/rest/api-group-boards/#api-boards-id-labels-get
-func (b *Board) Labels() (labels []Label, err error) {
-	body, err := b.client.Get("/boards/" + b.ID + "/labels")
+func (b *Board) Labels(opts ...QueryOption) (labels []Label, err error) {
+	return b.LabelsContext(context.Background(), opts...)
+}
+
+// LabelsContext - Get Labels on a Board (with context)
+func (b *Board) LabelsContext(ctx context.Context, opts ...QueryOption) (labels []Label, err error) {
+	if b.labels != nil && len(opts) == 0 {
+		return b.labels, nil
+	}
+	body, err := b.client.GetContext(ctx, encodeQuery("/boards/"+b.ID+"/labels", opts))
 	if err == nil {
 		labels, err = parseListLabels(body, b.client)
 	}
@@ -349,13 +493,19 @@ func (b *Board) Labels() (labels []Label, err error) {
 // - https://developer.atlassian.com/cloud/trello/rest/api-group-boards/#api-boards-id-labels-post
 // NOTE: Color can be an empty string
 func (b *Board) AddLabel(name, color string) (label *Label, err error) {
+	return b.AddLabelContext(context.Background(), name, color)
+}
+
+// AddLabelContext - Create a Label on a board (with context)
+func (b *Board) AddLabelContext(ctx context.Context, name, color string) (label *Label, err error) {
 	label = &Label{}
 	payload := url.Values{}
 	payload.Set("name", name)
 	payload.Set("color", color)
 
-	body, err := b.client.Post("/boards/"+b.ID+"/labels", payload)
+	body, err := b.client.PostContext(ctx, "/boards/"+b.ID+"/labels", payload)
 	if err == nil {
+		b.labels = nil
 		err = parseLabel(body, label, b.client)
 	}
 	return
@@ -372,6 +522,45 @@ func (b *Board) parseBoard(body []byte, client *Client) (err error) {
 			b.Memberships[i].client = client
 			b.Memberships[i].Board = b
 		}
+		err = b.parseNested(body, client)
+	}
+	return
+}
+
+// parseNested - Decode the nested resources requested with QueryOptions
+func (b *Board) parseNested(body []byte, client *Client) (err error) {
+	var nested struct {
+		Lists      json.RawMessage `json:"lists"`
+		Cards      json.RawMessage `json:"cards"`
+		Checklists json.RawMessage `json:"checklists"`
+		Labels     json.RawMessage `json:"labels"`
+		Actions    json.RawMessage `json:"actions"`
+	}
+	if err = json.Unmarshal(body, &nested); err != nil {
+		return
+	}
+	if nested.Lists != nil {
+		if b.lists, err = parseListLists(nested.Lists, client); err != nil {
+			return
+		}
+	}
+	if nested.Cards != nil {
+		if b.cards, err = parseListCards(nested.Cards, client); err != nil {
+			return
+		}
+	}
+	if nested.Checklists != nil {
+		if b.checklists, err = parseListChecklists(nested.Checklists, client); err != nil {
+			return
+		}
+	}
+	if nested.Labels != nil {
+		if b.labels, err = parseListLabels(nested.Labels, client); err != nil {
+			return
+		}
+	}
+	if nested.Actions != nil {
+		b.actions, err = parseListActions(nested.Actions, client)
 	}
 	return
 }
@@ -17,7 +17,7 @@ limitations under the License.
 package trello
 
 import (
-	"fmt"
+	"context"
 	"log"
 	"testing"
 	"time"
@@ -37,7 +37,7 @@ func TestBoard(t *testing.T) {
 		var testBoardName string
 
 		g.Before(func() {
-			testBoardName = fmt.Sprintf("GoTestTrello-Board-%v", time.Now().Unix())
+			testBoardName = testName("GoTestTrello-Board")
 			board, err = client.CreateBoard(testBoardName)
 			if err != nil || board == nil {
 				log.Fatal("ERROR Creating Board: " + err.Error())
@@ -140,6 +140,52 @@ func TestBoard(t *testing.T) {
 			Expect(err).To(BeNil())
 		})
 
+		g.It("should iterate over all the actions in a board one page at a time", func() {
+			actions, err := board.Actions()
+			Expect(err).To(BeNil())
+			Expect(len(actions)).To(BeNumerically(">", 2))
+
+			var ids []string
+			for action, err := range board.ActionsIter(context.Background(), ActionFilter{PageSize: 2}) {
+				Expect(err).To(BeNil())
+				Expect(action.client).NotTo(BeNil())
+				ids = append(ids, action.ID)
+			}
+			Expect(len(ids)).To(Equal(len(actions)))
+			for i := range actions {
+				Expect(ids[i]).To(Equal(actions[i].ID))
+			}
+		})
+
+		g.It("should iterate over filtered actions in a board", func() {
+			count := 0
+			for action, err := range board.ActionsIter(context.Background(), ActionFilter{Types: []ActionType{AddMemberToBoard}, PageSize: 1}) {
+				Expect(err).To(BeNil())
+				Expect(action.Type).To(Equal(AddMemberToBoard))
+				count++
+			}
+			Expect(count).To(BeNumerically(">", 0))
+		})
+
+		g.It("should stop iterating over actions at the cutoff date", func() {
+			// Derived from the board ID rather than the clock, so the query replays from a cassette
+			created, err := IDTime(board.ID)
+			Expect(err).To(BeNil())
+			for range board.ActionsIter(context.Background(), ActionFilter{Since: created.Add(time.Hour)}) {
+				g.Fail("no actions expected after the cutoff")
+			}
+		})
+
+		g.It("should stop iterating over actions when the loop breaks", func() {
+			count := 0
+			for _, err := range board.ActionsIter(context.Background(), ActionFilter{PageSize: 1}) {
+				Expect(err).To(BeNil())
+				count++
+				break
+			}
+			Expect(count).To(Equal(1))
+		})
+
 		g.It("should add a list to a board", func() {
 			list, err := board.AddList(List{
 				Name: "go-test",
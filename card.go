@@ -17,34 +17,46 @@ limitations under the License.
 package trello
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
 	"net/url"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 )
 
 // Card - Trello Card Type
 type Card struct {
 	client                *Client
-	ID                    string   `json:"id"`
-	Name                  string   `json:"name"`
-	Email                 string   `json:"email"`
-	IDShort               int      `json:"idShort"`
-	IDAttachmentCover     string   `json:"idAttachmentCover"`
-	IDCheckLists          []string `json:"idCheckLists"`
-	IDBoard               string   `json:"idBoard"`
-	IDList                string   `json:"idList"`
-	IDMembers             []string `json:"idMembers"`
-	IDMembersVoted        []string `json:"idMembersVoted"`
-	ManualCoverAttachment bool     `json:"manualCoverAttachment"`
-	Closed                bool     `json:"closed"`
-	Pos                   float64  `json:"pos"`
-	ShortLink             string   `json:"shortLink"`
-	DateLastActivity      string   `json:"dateLastActivity"`
-	ShortURL              string   `json:"shortUrl"`
-	Subscribed            bool     `json:"subscribed"`
-	URL                   string   `json:"url"`
-	Due                   string   `json:"due"`
-	Desc                  string   `json:"desc"`
+	ID                    string     `json:"id"`
+	Name                  string     `json:"name"`
+	Email                 string     `json:"email"`
+	IDShort               int        `json:"idShort"`
+	IDAttachmentCover     string     `json:"idAttachmentCover"`
+	IDCheckLists          []string   `json:"idCheckLists"`
+	IDBoard               string     `json:"idBoard"`
+	IDList                string     `json:"idList"`
+	IDMembers             []string   `json:"idMembers"`
+	IDMembersVoted        []string   `json:"idMembersVoted"`
+	ManualCoverAttachment bool       `json:"manualCoverAttachment"`
+	Closed                bool       `json:"closed"`
+	Pos                   float64    `json:"pos"`
+	ShortLink             string     `json:"shortLink"`
+	DateLastActivity      time.Time  `json:"dateLastActivity"`
+	ShortURL              string     `json:"shortUrl"`
+	Subscribed            bool       `json:"subscribed"`
+	URL                   string     `json:"url"`
+	Due                   *time.Time `json:"due"`
+	Start                 *time.Time `json:"start"`
+	DueComplete           bool       `json:"dueComplete"`
+	IDLabels              []string   `json:"idLabels"`
+	Address               string     `json:"address"`
+	LocationName          string     `json:"locationName"`
+	Desc                  string     `json:"desc"`
 	DescData              struct {
 		Emoji struct{} `json:"emoji"`
 	} `json:"descData"`
@@ -53,25 +65,39 @@ type Card struct {
 		State       string `json:"state"`
 	} `json:"checkItemStates"`
 	Badges struct {
-		Votes              int    `json:"votes"`
-		ViewingMemberVoted bool   `json:"viewingMemberVoted"`
-		Subscribed         bool   `json:"subscribed"`
-		Fogbugz            string `json:"fogbugz"`
-		CheckItems         int    `json:"checkItems"`
-		CheckItemsChecked  int    `json:"checkItemsChecked"`
-		Comments           int    `json:"comments"`
-		Attachments        int    `json:"attachments"`
-		Description        bool   `json:"description"`
-		Due                string `json:"due"`
+		Votes              int        `json:"votes"`
+		ViewingMemberVoted bool       `json:"viewingMemberVoted"`
+		Subscribed         bool       `json:"subscribed"`
+		Fogbugz            string     `json:"fogbugz"`
+		CheckItems         int        `json:"checkItems"`
+		CheckItemsChecked  int        `json:"checkItemsChecked"`
+		Comments           int        `json:"comments"`
+		Attachments        int        `json:"attachments"`
+		Description        bool       `json:"description"`
+		Due                *time.Time `json:"due"`
 	} `json:"badges"`
 	Labels []Label `json:"labels"`
+
+	// Nested resources, set when the card is fetched with IncludeChecklists, IncludeMembers...
+	checklists       []Checklist
+	members          []*Member
+	attachments      []Attachment
+	actions          []Action
+	customFieldItems []CustomFieldItem
 }
 
 // Card - Retrieve card by card ID
+// Nested resources requested with opts (IncludeChecklists, IncludeMembers...) are
+// returned by the corresponding Card methods without another request.
 // - https://developer.atlassian.com/cloud/trello/rest/api-group-cards/#api-cards-id-get
-func (c *Client) Card(CardID string) (card *Card, err error) {
+func (c *Client) Card(CardID string, opts ...QueryOption) (card *Card, err error) {
+	return c.CardContext(context.Background(), CardID, opts...)
+}
+
+// CardContext - Retrieve card by card ID (with context)
+func (c *Client) CardContext(ctx context.Context, CardID string, opts ...QueryOption) (card *Card, err error) {
 	card = &Card{}
-	body, err := c.Get("/card/" + CardID)
+	body, err := c.GetContext(ctx, encodeQuery("/card/"+CardID, opts))
 	if err == nil {
 		err = parseCard(body, card, c)
 	}
@@ -79,9 +105,18 @@ func (c *Client) Card(CardID string) (card *Card, err error) {
 }
 
 // Checklists - Get Checklists on a Card
+// Without opts the checklists loaded with IncludeChecklists are returned, if any.
 // - https://developer.atlassian.com/cloud/trello/rest/api-group-cards/#api-cards-id-checklists-get
-func (c *Card) Checklists() (checklists []Checklist, err error) {
-	body, err := c.client.Get("/card/" + c.ID + "/checklists")
+func (c *Card) Checklists(opts ...QueryOption) (checklists []Checklist, err error) {
+	return c.ChecklistsContext(context.Background(), opts...)
+}
+
+// ChecklistsContext - Get Checklists on a Card (with context)
+func (c *Card) ChecklistsContext(ctx context.Context, opts ...QueryOption) (checklists []Checklist, err error) {
+	if c.checklists != nil && len(opts) == 0 {
+		return c.checklists, nil
+	}
+	body, err := c.client.GetContext(ctx, encodeQuery("/card/"+c.ID+"/checklists", opts))
 	if err == nil {
 		checklists, err = parseListChecklists(body, c.client)
 	}
@@ -89,9 +124,18 @@ func (c *Card) Checklists() (checklists []Checklist, err error) {
 }
 
 // Members - Get the Members of a card
+// Without opts the members loaded with IncludeMembers are returned, if any.
 // - https://developer.atlassian.com/cloud/trello/rest/api-group-cards/#api-cards-id-members-get
-func (c *Card) Members() (members []*Member, err error) {
-	body, err := c.client.Get("/cards/" + c.ID + "/members")
+func (c *Card) Members(opts ...QueryOption) (members []*Member, err error) {
+	return c.MembersContext(context.Background(), opts...)
+}
+
+// MembersContext - Get the Members of a card (with context)
+func (c *Card) MembersContext(ctx context.Context, opts ...QueryOption) (members []*Member, err error) {
+	if c.members != nil && len(opts) == 0 {
+		return c.members, nil
+	}
+	body, err := c.client.GetContext(ctx, encodeQuery("/cards/"+c.ID+"/members", opts))
 	if err == nil {
 		members, err = parseListMembers(body, c.client)
 	}
@@ -104,10 +148,16 @@ func (c *Card) Members() (members []*Member, err error) {
 // It returns the resulting member-list
 // https://developers.trello.com/v1.0/reference#cardsididmembers
 func (c *Card) AddMember(member *Member) (members []*Member, err error) {
+	return c.AddMemberContext(context.Background(), member)
+}
+
+// AddMemberContext - Add a member to a card (with context)
+func (c *Card) AddMemberContext(ctx context.Context, member *Member) (members []*Member, err error) {
 	payload := url.Values{}
 	payload.Set("value", member.ID)
-	body, err := c.client.Post("/cards/"+c.ID+"/idMembers", payload)
+	body, err := c.client.PostContext(ctx, "/cards/"+c.ID+"/idMembers", payload)
 	if err == nil {
+		c.members = nil
 		members, err = parseListMembers(body, c.client)
 	}
 	return
@@ -118,19 +168,35 @@ func (c *Card) AddMember(member *Member) (members []*Member, err error) {
 // The RemoveMember function requires a member (pointer) to delete
 // It returns the resulting member-list
 func (c *Card) RemoveMember(member *Member) (members []*Member, err error) {
-	body, err := c.client.Delete("/cards/" + c.ID + "/idMembers/" + member.ID)
+	return c.RemoveMemberContext(context.Background(), member)
+}
+
+// RemoveMemberContext - Remove a member from a card (with context)
+func (c *Card) RemoveMemberContext(ctx context.Context, member *Member) (members []*Member, err error) {
+	body, err := c.client.DeleteContext(ctx, "/cards/"+c.ID+"/idMembers/"+member.ID)
 	if err == nil {
+		c.members = nil
 		members, err = parseListMembers(body, c.client)
 	}
-	return members, nil
+	return
 }
 
 // Attachments - Get Attachments on a Card
+// Without opts the attachments loaded with IncludeAttachments are returned, if any.
 // - https://developer.atlassian.com/cloud/trello/rest/api-group-cards/#api-cards-id-attachments-get
-func (c *Card) Attachments() (attachments []Attachment, err error) {
-	body, err := c.client.Get("/cards/" + c.ID + "/attachments")
+func (c *Card) Attachments(opts ...QueryOption) (attachments []Attachment, err error) {
+	return c.AttachmentsContext(context.Background(), opts...)
+}
+
+// AttachmentsContext - Get Attachments on a Card (with context)
+func (c *Card) AttachmentsContext(ctx context.Context, opts ...QueryOption) (attachments []Attachment, err error) {
+	if c.attachments != nil && len(opts) == 0 {
+		return c.attachments, nil
+	}
+	body, err := c.client.GetContext(ctx, encodeQuery("/cards/"+c.ID+"/attachments", opts))
 	if err == nil {
 		attachments, err = parseListAttachments(body, c.client)
+		setAttachmentsCard(attachments, c.ID)
 	}
 	return
 }
@@ -139,32 +205,160 @@ func (c *Card) Attachments() (attachments []Attachment, err error) {
 // - https://developer.atlassian.com/cloud/trello/rest/api-group-cards/#api-cards-id-attachments-idattachment-get
 // https://developers.trello.com/advanced-reference/card#get-1-cards-card-id-or-shortlink-attachments-idattachment
 func (c *Card) Attachment(attachmentID string) (attachment *Attachment, err error) {
+	return c.AttachmentContext(context.Background(), attachmentID)
+}
+
+// AttachmentContext will return the specified attachment on the card (with context)
+func (c *Card) AttachmentContext(ctx context.Context, attachmentID string) (attachment *Attachment, err error) {
 	attachment = &Attachment{}
-	body, err := c.client.Get("/cards/" + c.ID + "/attachments/" + attachmentID)
+	body, err := c.client.GetContext(ctx, "/cards/"+c.ID+"/attachments/"+attachmentID)
 	if err == nil {
 		err = parseAttachment(body, attachment, c.client)
+		attachment.cardID = c.ID
+	}
+	return
+}
+
+// AttachFile - Upload a file to a Card
+// The file is streamed as a multipart request, so it is never held in memory, and
+// the request cannot be retried. mimeType may be empty.
+// - https://developer.atlassian.com/cloud/trello/rest/api-group-cards/#api-cards-id-attachments-post
+func (c *Card) AttachFile(name string, file io.Reader, mimeType string) (attachment *Attachment, err error) {
+	return c.AttachFileContext(context.Background(), name, file, mimeType)
+}
+
+// AttachFileContext - Upload a file to a Card (with context)
+func (c *Card) AttachFileContext(ctx context.Context, name string, file io.Reader, mimeType string) (attachment *Attachment, err error) {
+	attachment = &Attachment{}
+	fields := url.Values{}
+	fields.Set("name", name)
+	body, err := c.client.postMultipart(ctx, "/cards/"+c.ID+"/attachments", fields, "file", name, file, mimeType)
+	if err == nil {
+		c.attachments = nil
+		err = parseAttachment(body, attachment, c.client)
+		attachment.cardID = c.ID
+	}
+	return
+}
+
+// AttachURL - Attach a link to a Card (name may be empty)
+// - https://developer.atlassian.com/cloud/trello/rest/api-group-cards/#api-cards-id-attachments-post
+func (c *Card) AttachURL(attachmentURL, name string) (attachment *Attachment, err error) {
+	return c.AttachURLContext(context.Background(), attachmentURL, name)
+}
+
+// AttachURLContext - Attach a link to a Card (with context)
+func (c *Card) AttachURLContext(ctx context.Context, attachmentURL, name string) (attachment *Attachment, err error) {
+	attachment = &Attachment{}
+	payload := url.Values{}
+	payload.Set("url", attachmentURL)
+	if name != "" {
+		payload.Set("name", name)
+	}
+	body, err := c.client.PostContext(ctx, "/cards/"+c.ID+"/attachments", payload)
+	if err == nil {
+		c.attachments = nil
+		err = parseAttachment(body, attachment, c.client)
+		attachment.cardID = c.ID
+	}
+	return
+}
+
+// SetCover - Show an uploaded image attachment on the front of the Card (nil removes the cover)
+// - https://developer.atlassian.com/cloud/trello/rest/api-group-cards/#api-cards-id-put
+func (c *Card) SetCover(attachment *Attachment) (err error) {
+	return c.SetCoverContext(context.Background(), attachment)
+}
+
+// SetCoverContext - Show an uploaded image attachment on the front of the Card (with context)
+func (c *Card) SetCoverContext(ctx context.Context, attachment *Attachment) (err error) {
+	payload := url.Values{}
+	if attachment != nil {
+		payload.Set("value", attachment.ID)
+	} else {
+		payload.Set("value", "")
+	}
+	body, err := c.client.PutContext(ctx, "/cards/"+c.ID+"/idAttachmentCover", payload)
+	if err == nil {
+		c.IDAttachmentCover = "" // null when removed, which would leave the old value
+		err = parseCard(body, c, c.client)
 	}
 	return
 }
 
 // Actions - Get Actions on a card
+// Without opts the actions loaded with IncludeActions are returned, if any.
 // - https://developer.atlassian.com/cloud/trello/rest/api-group-cards/#api-cards-id-actions-get
-func (c *Card) Actions() (actions []Action, err error) {
-	body, err := c.client.Get("/cards/" + c.ID + "/actions")
+func (c *Card) Actions(opts ...QueryOption) (actions []Action, err error) {
+	return c.ActionsContext(context.Background(), opts...)
+}
+
+// ActionsContext - Get Actions on a card (with context)
+func (c *Card) ActionsContext(ctx context.Context, opts ...QueryOption) (actions []Action, err error) {
+	if c.actions != nil && len(opts) == 0 {
+		return c.actions, nil
+	}
+	body, err := c.client.GetContext(ctx, encodeQuery("/cards/"+c.ID+"/actions", opts))
 	if err == nil {
 		actions, err = parseListActions(body, c.client)
 	}
 	return
 }
 
+// ActionsIter - Iterate over all Actions on a card, newest first, fetching pages as needed
+// - https://developer.atlassian.com/cloud/trello/rest/api-group-cards/#api-cards-id-actions-get
+func (c *Card) ActionsIter(ctx context.Context, filter ActionFilter) iter.Seq2[Action, error] {
+	return c.client.actionsIter(ctx, "/cards/"+c.ID+"/actions", filter)
+}
+
+// Comments - Get the comments on a card (commentCard Actions), newest first
+// opts can page through them, e.g. NewArgument("limit", "10") or NewArgument("before", id).
+// - https://developer.atlassian.com/cloud/trello/rest/api-group-cards/#api-cards-id-actions-get
+func (c *Card) Comments(opts ...QueryOption) (comments []Action, err error) {
+	return c.CommentsContext(context.Background(), opts...)
+}
+
+// CommentsContext - Get the comments on a card (with context)
+func (c *Card) CommentsContext(ctx context.Context, opts ...QueryOption) (comments []Action, err error) {
+	opts = append([]QueryOption{NewArgument("filter", string(CommentCard))}, opts...)
+	body, err := c.client.GetContext(ctx, encodeQuery("/cards/"+c.ID+"/actions", opts))
+	if err == nil {
+		comments, err = parseListActions(body, c.client)
+	}
+	return
+}
+
 // AddChecklist - Create a Checklist on a Card
 // - https://developer.atlassian.com/cloud/trello/rest/api-group-cards/#api-cards-id-checklists-post
 func (c *Card) AddChecklist(name string) (checklist *Checklist, err error) {
-	checklist = &Checklist{}
+	return c.AddChecklistContext(context.Background(), name)
+}
+
+// AddChecklistContext - Create a Checklist on a Card (with context)
+func (c *Card) AddChecklistContext(ctx context.Context, name string) (checklist *Checklist, err error) {
 	payload := url.Values{}
 	payload.Set("name", name)
-	body, err := c.client.Post("/cards/"+c.ID+"/checklists", payload)
+	return c.addChecklist(ctx, payload)
+}
+
+// AddChecklistFrom - Create a copy of a Checklist (of any card) on a Card, with the same name and items
+// - https://developer.atlassian.com/cloud/trello/rest/api-group-cards/#api-cards-id-checklists-post
+func (c *Card) AddChecklistFrom(source *Checklist) (checklist *Checklist, err error) {
+	return c.AddChecklistFromContext(context.Background(), source)
+}
+
+// AddChecklistFromContext - Create a copy of a Checklist on a Card (with context)
+func (c *Card) AddChecklistFromContext(ctx context.Context, source *Checklist) (checklist *Checklist, err error) {
+	payload := url.Values{}
+	payload.Set("idChecklistSource", source.ID)
+	return c.addChecklist(ctx, payload)
+}
+
+func (c *Card) addChecklist(ctx context.Context, payload url.Values) (checklist *Checklist, err error) {
+	checklist = &Checklist{}
+	body, err := c.client.PostContext(ctx, "/cards/"+c.ID+"/checklists", payload)
 	if err == nil {
+		c.checklists = nil
 		err = parseChecklist(body, checklist, c.client)
 	}
 	return
@@ -173,37 +367,165 @@ func (c *Card) AddChecklist(name string) (checklist *Checklist, err error) {
 // AddComment will add a new comment to the card
 // https://developers.trello.com/advanced-reference/card#post-1-cards-card-id-or-shortlink-actions-comments
 func (c *Card) AddComment(text string) (action *Action, err error) {
+	return c.AddCommentContext(context.Background(), text)
+}
+
+// AddCommentContext will add a new comment to the card (with context)
+func (c *Card) AddCommentContext(ctx context.Context, text string) (action *Action, err error) {
 	action = &Action{}
 	payload := url.Values{}
 	payload.Set("text", text)
 
-	body, err := c.client.Post("/cards/"+c.ID+"/actions/comments", payload)
+	body, err := c.client.PostContext(ctx, "/cards/"+c.ID+"/actions/comments", payload)
 	if err == nil {
+		c.actions = nil
 		err = parseAction(body, action, c.client)
 	}
 	return
 }
 
 // MoveToList - Move a card to a list
+// Use MoveToBoard when the list is on another board.
 func (c *Card) MoveToList(dstList List) (err error) {
+	return c.MoveToListContext(context.Background(), dstList)
+}
+
+// MoveToListContext - Move a card to a list (with context)
+func (c *Card) MoveToListContext(ctx context.Context, dstList List) (err error) {
 	payload := url.Values{}
 	payload.Set("value", dstList.ID)
 
-	body, err := c.client.Put("/cards/"+c.ID+"/idList", payload)
+	body, err := c.client.PutContext(ctx, "/cards/"+c.ID+"/idList", payload)
 	if err == nil {
 		err = parseCard(body, c, c.client)
 	}
 	return
 }
 
+// MoveCardOptions - Options of Card.MoveToBoard
+type MoveCardOptions struct {
+	// Pos is "top", "bottom" or a positive number ("bottom" if empty)
+	Pos string
+	// CreateLabels creates the labels missing on the destination board instead of removing them
+	CreateLabels bool
+}
+
+// MoveToBoard - Move a card to a list of another board (the first list if list is nil)
+// Labels are replaced by the labels of the destination board with the same name and color,
+// and members who are not on the destination board are removed from the card.
+// - https://developer.atlassian.com/cloud/trello/rest/api-group-cards/#api-cards-id-put
+func (c *Card) MoveToBoard(board *Board, list *List, opts MoveCardOptions) (err error) {
+	return c.MoveToBoardContext(context.Background(), board, list, opts)
+}
+
+// MoveToBoardContext - Move a card to a list of another board (with context)
+func (c *Card) MoveToBoardContext(ctx context.Context, board *Board, list *List, opts MoveCardOptions) (err error) {
+	if list != nil && list.IDBoard != board.ID {
+		return fmt.Errorf("List %s is not on board %s", list.ID, board.ID)
+	}
+	// The labels and members of board may be stale (or nested), so they are fetched again
+	body, err := board.client.GetContext(ctx, "/boards/"+board.ID+"/labels")
+	if err != nil {
+		return
+	}
+	labels, err := parseListLabels(body, board.client)
+	if err != nil {
+		return
+	}
+	idLabels := []string{}
+	for _, label := range c.Labels {
+		i := slices.IndexFunc(labels, func(l Label) bool { return l.Name == label.Name && l.Color == label.Color })
+		if i >= 0 {
+			idLabels = append(idLabels, labels[i].ID)
+		} else if opts.CreateLabels {
+			created, err := board.AddLabelContext(ctx, label.Name, label.Color)
+			if err != nil {
+				return err
+			}
+			labels = append(labels, *created)
+			idLabels = append(idLabels, created.ID)
+		}
+	}
+	body, err = board.client.GetContext(ctx, "/boards/"+board.ID+"/members")
+	if err != nil {
+		return
+	}
+	members, err := parseListMembers(body, board.client)
+	if err != nil {
+		return
+	}
+	idMembers := []string{}
+	for _, id := range c.IDMembers {
+		if slices.ContainsFunc(members, func(m *Member) bool { return m.ID == id }) {
+			idMembers = append(idMembers, id)
+		}
+	}
+
+	update := CardUpdate{IDBoard: &board.ID, IDLabels: idLabels, IDMembers: idMembers}
+	if list != nil {
+		update.IDList = &list.ID
+	}
+	pos := opts.Pos
+	if pos == "" {
+		pos = "bottom"
+	}
+	update.Pos = &pos
+	return c.UpdateContext(ctx, update)
+}
+
+// CopyTo - Copy a card to a list, of this board or another one, keeping the given
+// properties of the card (everything if none is given)
+// - https://developer.atlassian.com/cloud/trello/rest/api-group-cards/#api-cards-post
+func (c *Card) CopyTo(list *List, keep ...CardProperty) (card *Card, err error) {
+	return c.CopyToContext(context.Background(), list, keep...)
+}
+
+// CopyToContext - Copy a card to a list (with context)
+func (c *Card) CopyToContext(ctx context.Context, list *List, keep ...CardProperty) (card *Card, err error) {
+	return list.CreateCardContext(ctx, CreateCardOptions{IDCardSource: c.ID, KeepFromSource: keep})
+}
+
+// ToChecklistItem - Replace a Card by an item of checklist, named after the card and linking
+// back to it, with the due date of the card; the card is archived
+// - https://developer.atlassian.com/cloud/trello/rest/api-group-checklists/#api-checklists-id-checkitems-post
+func (c *Card) ToChecklistItem(checklist *Checklist) (item *ChecklistItem, err error) {
+	return c.ToChecklistItemContext(context.Background(), checklist)
+}
+
+// ToChecklistItemContext - Replace a Card by an item of checklist (with context)
+func (c *Card) ToChecklistItemContext(ctx context.Context, checklist *Checklist) (item *ChecklistItem, err error) {
+	link := c.ShortURL
+	if link == "" {
+		link = c.URL
+	}
+	item, err = checklist.AddItemContext(ctx, c.Name+" "+link, "bottom", false)
+	if err != nil {
+		return nil, err
+	}
+	if c.Due != nil {
+		if err = item.SetDueContext(ctx, c.Due); err != nil {
+			return
+		}
+	}
+	if err = c.ArchiveContext(ctx, true); err == nil {
+		c.Closed = true
+	}
+	return
+}
+
 // Move - Move Card Position (Update a Card)
 // - https://developer.atlassian.com/cloud/trello/rest/api-group-cards/#api-cards-id-put
 //pos can be "bottom", "top" or a positive number
 func (c *Card) Move(pos string) (err error) {
+	return c.MoveContext(context.Background(), pos)
+}
+
+// MoveContext - Move Card Position (with context)
+func (c *Card) MoveContext(ctx context.Context, pos string) (err error) {
 	payload := url.Values{}
 	payload.Set("value", pos)
 
-	body, err := c.client.Put("/cards/"+c.ID+"/pos", payload)
+	body, err := c.client.PutContext(ctx, "/cards/"+c.ID+"/pos", payload)
 	if err == nil {
 		err = parseCard(body, c, c.client)
 	}
@@ -213,7 +535,12 @@ func (c *Card) Move(pos string) (err error) {
 // Delete - Delete a Card
 // - https://developer.atlassian.com/cloud/trello/rest/api-group-cards/#api-cards-id-delete
 func (c *Card) Delete() error {
-	_, err := c.client.Delete("/cards/" + c.ID)
+	return c.DeleteContext(context.Background())
+}
+
+// DeleteContext - Delete a Card (with context)
+func (c *Card) DeleteContext(ctx context.Context) error {
+	_, err := c.client.DeleteContext(ctx, "/cards/"+c.ID)
 	return err
 }
 
@@ -221,20 +548,30 @@ func (c *Card) Delete() error {
 // - https://developer.atlassian.com/cloud/trello/rest/api-group-cards/#api-cards-id-put
 //If mode is true, card is archived, otherwise it's unarchived (returns to the board)
 func (c *Card) Archive(mode bool) error {
+	return c.ArchiveContext(context.Background(), mode)
+}
+
+// ArchiveContext - Archive (close) a Card (with context)
+func (c *Card) ArchiveContext(ctx context.Context, mode bool) error {
 	payload := url.Values{}
 	payload.Set("value", strconv.FormatBool(mode))
 
-	_, err := c.client.Put("/cards/"+c.ID+"/closed", payload)
+	_, err := c.client.PutContext(ctx, "/cards/"+c.ID+"/closed", payload)
 	return err
 }
 
 // SetName - Set Name on Card (Update a Card)
 // - https://developer.atlassian.com/cloud/trello/rest/api-group-cards/#api-cards-id-put
 func (c *Card) SetName(name string) (err error) {
+	return c.SetNameContext(context.Background(), name)
+}
+
+// SetNameContext - Set Name on Card (with context)
+func (c *Card) SetNameContext(ctx context.Context, name string) (err error) {
 	payload := url.Values{}
 	payload.Set("value", name)
 
-	body, err := c.client.Put("/cards/"+c.ID+"/name", payload)
+	body, err := c.client.PutContext(ctx, "/cards/"+c.ID+"/name", payload)
 	if err == nil {
 		err = parseCard(body, c, c.client)
 	}
@@ -244,24 +581,116 @@ func (c *Card) SetName(name string) (err error) {
 // SetDescription - Set Description on Card (Update a Card)
 // - https://developer.atlassian.com/cloud/trello/rest/api-group-cards/#api-cards-id-put
 func (c *Card) SetDescription(desc string) (err error) {
+	return c.SetDescriptionContext(context.Background(), desc)
+}
+
+// SetDescriptionContext - Set Description on Card (with context)
+func (c *Card) SetDescriptionContext(ctx context.Context, desc string) (err error) {
 	payload := url.Values{}
 	payload.Set("value", desc)
 
-	body, err := c.client.Put("/cards/"+c.ID+"/desc", payload)
+	body, err := c.client.PutContext(ctx, "/cards/"+c.ID+"/desc", payload)
 	if err == nil {
 		err = parseCard(body, c, c.client)
 	}
 	return
 }
 
+// CardUpdate - Changes to a Card, for Card.Update
+// Nil fields are left unchanged; a non-nil empty IDLabels or IDMembers removes them all.
+type CardUpdate struct {
+	Name         *string
+	Desc         *string
+	Closed       *bool
+	Due          *time.Time
+	RemoveDue    bool
+	Start        *time.Time
+	RemoveStart  bool
+	DueComplete  *bool
+	IDList       *string
+	IDBoard      *string
+	Pos          *string // "top", "bottom" or a positive number
+	IDLabels     []string
+	IDMembers    []string
+	Address      *string
+	LocationName *string
+	Coordinates  *string // "latitude,longitude"
+}
+
+// values - Encode the changes as the form of a card update request
+func (u CardUpdate) values() url.Values {
+	payload := url.Values{}
+	setString := func(key string, value *string) {
+		if value != nil {
+			payload.Set(key, *value)
+		}
+	}
+	setBool := func(key string, value *bool) {
+		if value != nil {
+			payload.Set(key, strconv.FormatBool(*value))
+		}
+	}
+	setDate := func(key string, value *time.Time, remove bool) {
+		if remove {
+			payload.Set(key, "null")
+		} else if value != nil {
+			payload.Set(key, value.UTC().Format(time.RFC3339Nano))
+		}
+	}
+	setIDs := func(key string, ids []string) {
+		if ids != nil {
+			payload.Set(key, strings.Join(ids, ","))
+		}
+	}
+	setString("name", u.Name)
+	setString("desc", u.Desc)
+	setBool("closed", u.Closed)
+	setDate("due", u.Due, u.RemoveDue)
+	setDate("start", u.Start, u.RemoveStart)
+	setBool("dueComplete", u.DueComplete)
+	setString("idList", u.IDList)
+	setString("idBoard", u.IDBoard)
+	setString("pos", u.Pos)
+	setIDs("idLabels", u.IDLabels)
+	setIDs("idMembers", u.IDMembers)
+	setString("address", u.Address)
+	setString("locationName", u.LocationName)
+	setString("coordinates", u.Coordinates)
+	return payload
+}
+
+// Update - Change several fields of a Card in a single request (and a single updateCard action)
+// The Card is refreshed with the result; nested resources loaded with QueryOptions are dropped.
+// - https://developer.atlassian.com/cloud/trello/rest/api-group-cards/#api-cards-id-put
+func (c *Card) Update(update CardUpdate) (err error) {
+	return c.UpdateContext(context.Background(), update)
+}
+
+// UpdateContext - Change several fields of a Card in a single request (with context)
+func (c *Card) UpdateContext(ctx context.Context, update CardUpdate) (err error) {
+	body, err := c.client.PutContext(ctx, "/cards/"+c.ID, update.values())
+	if err == nil {
+		updated := Card{}
+		if err = parseCard(body, &updated, c.client); err == nil {
+			*c = updated
+		}
+	}
+	return
+}
+
 // AddLabel - Add Label to a Card
 // - https://developer.atlassian.com/cloud/trello/rest/api-group-cards/#api-cards-id-idlabels-post
 // Returns an array of cards labels ids
 func (c *Card) AddLabel(id string) (ids []string, err error) {
+	return c.AddLabelContext(context.Background(), id)
+}
+
+// AddLabelContext - Add Label to a Card (with context)
+func (c *Card) AddLabelContext(ctx context.Context, id string) (ids []string, err error) {
 	payload := url.Values{}
 	payload.Set("value", id)
 
-	body, err := c.client.Post("/cards/"+c.ID+"/idLabels", payload)
+	body, err := c.client.PostContext(ctx, "/cards/"+c.ID+"/idLabels", payload)
 	if err == nil {
 		err = json.Unmarshal(body, &ids)
 	}
@@ -271,12 +700,17 @@ func (c *Card) AddLabel(id string) (ids []string, err error) {
 // AddNewLabel - Add a Label to a Card
 // - https://developer.atlassian.com/cloud/trello/rest/api-group-cards/#api-cards-id-idlabels-post
 func (c *Card) AddNewLabel(name, color string) (label *Label, err error) {
+	return c.AddNewLabelContext(context.Background(), name, color)
+}
+
+// AddNewLabelContext - Add a Label to a Card (with context)
+func (c *Card) AddNewLabelContext(ctx context.Context, name, color string) (label *Label, err error) {
 	label = &Label{}
 	payload := url.Values{}
 	payload.Set("name", name)
 	payload.Set("color", color)
 
-	body, err := c.client.Post("/cards/"+c.ID+"/labels", payload)
+	body, err := c.client.PostContext(ctx, "/cards/"+c.ID+"/labels", payload)
 	if err == nil {
 		err = parseLabel(body, label, c.client)
 	}
@@ -287,16 +721,62 @@ func parseCard(body []byte, card *Card, client *Client) (err error) {
 	err = json.Unmarshal(body, &card)
 	if err == nil {
 		card.client = client
+		for i := range card.Labels {
+			card.Labels[i].client = client
+		}
+		err = card.parseNested(body, client)
+	}
+	return
+}
+
+// parseNested - Decode the nested resources requested with QueryOptions
+func (c *Card) parseNested(body []byte, client *Client) (err error) {
+	var nested struct {
+		Checklists       json.RawMessage `json:"checklists"`
+		Members          json.RawMessage `json:"members"`
+		Attachments      json.RawMessage `json:"attachments"`
+		Actions          json.RawMessage `json:"actions"`
+		CustomFieldItems json.RawMessage `json:"customFieldItems"`
+	}
+	if err = json.Unmarshal(body, &nested); err != nil {
+		return
+	}
+	if nested.Checklists != nil {
+		if c.checklists, err = parseListChecklists(nested.Checklists, client); err != nil {
+			return
+		}
+	}
+	if nested.Members != nil {
+		if c.members, err = parseListMembers(nested.Members, client); err != nil {
+			return
+		}
+	}
+	if nested.Attachments != nil {
+		if c.attachments, err = parseListAttachments(nested.Attachments, client); err != nil {
+			return
+		}
+		setAttachmentsCard(c.attachments, c.ID)
+	}
+	if nested.Actions != nil {
+		if c.actions, err = parseListActions(nested.Actions, client); err != nil {
+			return
+		}
+	}
+	if nested.CustomFieldItems != nil {
+		err = json.Unmarshal(nested.CustomFieldItems, &c.customFieldItems)
 	}
 	return
 }
 
 func parseListCards(body []byte, client *Client) (cards []Card, err error) {
-	err = json.Unmarshal(body, &cards)
-	for i := range cards {
-		cards[i].client = client
-		for j := range cards[i].Labels {
-			cards[i].Labels[j].client = client
+	var raw []json.RawMessage
+	err = json.Unmarshal(body, &raw)
+	if err == nil && raw != nil {
+		cards = make([]Card, len(raw))
+		for i := range raw {
+			if err = parseCard(raw[i], &cards[i], client); err != nil {
+				return nil, err
+			}
 		}
 	}
 	return
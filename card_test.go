@@ -17,8 +17,11 @@ limitations under the License.
 package trello
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"net/http"
+	"strings"
 	"testing"
 	"time"
 
@@ -26,6 +29,23 @@ import (
 	. "github.com/onsi/gomega"
 )
 
+// failingTransport - Fail the requests whose path ends with suffix, sending the others through the delegate
+type failingTransport struct {
+	delegate http.RoundTripper
+	suffix   string
+}
+
+func (t *failingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if strings.HasSuffix(req.URL.Path, t.suffix) {
+		return nil, fmt.Errorf("Request to %s refused", req.URL.Path)
+	}
+	delegate := t.delegate
+	if delegate == nil {
+		delegate = http.DefaultTransport
+	}
+	return delegate.RoundTrip(req)
+}
+
 func TestCard(t *testing.T) {
 	g := goblin.Goblin(t)
 	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
@@ -38,7 +58,7 @@ func TestCard(t *testing.T) {
 		var testBoardName string
 
 		g.Before(func() {
-			testBoardName = fmt.Sprintf("GoTestTrello-Card-%v", time.Now().Unix())
+			testBoardName = testName("GoTestTrello-Card")
 			member, err = client.Member("me")
 			if err != nil || member == nil {
 				log.Fatal("ERROR Retrieving member (me): " + err.Error())
@@ -97,6 +117,11 @@ func TestCard(t *testing.T) {
 			_, err = card.RemoveMember(member)
 			Expect(err).To(BeNil())
 			// It might be nice to check if "me" is NOT in the members?
+
+			canceled, cancel := context.WithCancel(context.Background())
+			cancel()
+			_, err = card.RemoveMemberContext(canceled, member)
+			Expect(err).NotTo(BeNil())
 		})
 
 		g.It("should get the attachments on a card", func() {
@@ -127,6 +152,16 @@ func TestCard(t *testing.T) {
 			// It might be nice to check attachments?
 		})
 
+		g.It("should iterate over the actions on a card", func() {
+			count := 0
+			for action, err := range card.ActionsIter(context.Background(), ActionFilter{PageSize: 1}) {
+				Expect(err).To(BeNil())
+				Expect(action.Data.Card.ID).To(Equal(card.ID))
+				count++
+			}
+			Expect(count).To(BeNumerically(">", 0))
+		})
+
 		g.It("should add a checklist to a card", func() {
 			checklist, err := card.AddChecklist("TrelloChecklistTest")
 			Expect(err).To(BeNil())
@@ -189,6 +224,50 @@ func TestCard(t *testing.T) {
 			Expect(err).To(BeNil())
 		})
 
+		g.It("should update several fields of a card at once", func() {
+			lists, err := board.Lists()
+			Expect(err).To(BeNil())
+			before, err := card.Actions()
+			Expect(err).To(BeNil())
+
+			name, desc, pos, complete := "Updated", "Updated in one request", "top", true
+			due := time.Date(2030, 1, 2, 15, 4, 5, 0, time.UTC)
+			err = card.Update(CardUpdate{
+				Name:        &name,
+				Desc:        &desc,
+				Due:         &due,
+				DueComplete: &complete,
+				IDList:      &lists[0].ID,
+				Pos:         &pos,
+				IDLabels:    []string{},
+			})
+			Expect(err).To(BeNil())
+			Expect(card.Name).To(Equal(name))
+			Expect(card.Desc).To(Equal(desc))
+			Expect(card.Due.Equal(due)).To(BeTrue())
+			Expect(card.DueComplete).To(BeTrue())
+			Expect(card.IDList).To(Equal(lists[0].ID))
+			Expect(card.IDLabels).To(BeEmpty())
+
+			after, err := card.Actions()
+			Expect(err).To(BeNil())
+			Expect(len(after)).To(Equal(len(before) + 1))
+			Expect(after[0].Type).To(Equal(UpdateCard))
+		})
+
+		g.It("should remove the due date of a card", func() {
+			Expect(card.Update(CardUpdate{RemoveDue: true})).To(BeNil())
+			Expect(card.Due).To(BeNil())
+		})
+
+		g.It("should reject an invalid update as a whole", func() {
+			name, pos := "Not applied", "sideways"
+			Expect(card.Update(CardUpdate{Name: &name, Pos: &pos})).NotTo(BeNil())
+			fetched, err := client.Card(card.ID)
+			Expect(err).To(BeNil())
+			Expect(fetched.Name).To(Equal("Updated"))
+		})
+
 		// Add this board test here, cause it gets cards
 		g.It("should get the cards in a board", func() {
 			_, err := board.Cards()
@@ -222,3 +301,122 @@ func TestCard(t *testing.T) {
 	})
 
 }
+
+func TestCardAcrossBoards(t *testing.T) {
+	g := goblin.Goblin(t)
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+
+	g.Describe("Card move and copy tests", func() {
+		var source, target *Board
+		var sourceList, targetList *List
+		var card *Card
+
+		g.Before(func() {
+			source, err = client.CreateBoard(testName("GoTestTrello-MoveSource"))
+			Expect(err).To(BeNil())
+			target, err = client.CreateBoard(testName("GoTestTrello-MoveTarget"))
+			Expect(err).To(BeNil())
+			lists, err := source.Lists()
+			Expect(err).To(BeNil())
+			sourceList = &lists[0]
+			lists, err = target.Lists()
+			Expect(err).To(BeNil())
+			targetList = &lists[1]
+
+			bug, err := source.AddLabel("Bug", "red")
+			Expect(err).To(BeNil())
+			idea, err := source.AddLabel("Idea", "sky")
+			Expect(err).To(BeNil())
+			_, err = target.AddLabel("Bug", "red")
+			Expect(err).To(BeNil())
+
+			other, err := client.Member("test")
+			Expect(err).To(BeNil())
+			Expect(source.AddMember(other, "normal")).To(BeNil())
+			me, err := client.Member("me")
+			Expect(err).To(BeNil())
+
+			card, err = sourceList.CreateCard(CreateCardOptions{
+				Name:      "Traveller",
+				IDLabels:  []string{bug.ID, idea.ID},
+				IDMembers: []string{me.ID, other.ID},
+			})
+			Expect(err).To(BeNil())
+			Expect(len(card.Labels)).To(Equal(2))
+		})
+
+		g.It("should copy a card to another board", func() {
+			_, err = card.AddChecklist("Steps")
+			Expect(err).To(BeNil())
+			copied, err := card.CopyTo(targetList, KeepChecklists)
+			Expect(err).To(BeNil())
+			Expect(copied.ID).NotTo(Equal(card.ID))
+			Expect(copied.Name).To(Equal("Traveller"))
+			Expect(copied.IDBoard).To(Equal(target.ID))
+			Expect(copied.IDMembers).To(BeEmpty())
+			checklists, err := copied.Checklists()
+			Expect(err).To(BeNil())
+			Expect(len(checklists)).To(Equal(1))
+
+			copied, err = card.CopyTo(sourceList)
+			Expect(err).To(BeNil())
+			Expect(len(copied.IDLabels)).To(Equal(2))
+		})
+
+		g.It("should refuse a list of another board", func() {
+			Expect(card.MoveToBoard(target, sourceList, MoveCardOptions{})).NotTo(BeNil())
+		})
+
+		g.It("should leave the card alone when the members of the board cannot be fetched", func() {
+			failing := *client
+			failing.client = &http.Client{Transport: &failingTransport{delegate: client.client.Transport, suffix: "/members"}}
+			failing.retryPolicy = nil
+			board := *target
+			board.client = &failing
+
+			Expect(card.MoveToBoard(&board, targetList, MoveCardOptions{})).NotTo(BeNil())
+			fetched, err := client.Card(card.ID)
+			Expect(err).To(BeNil())
+			Expect(fetched.IDBoard).To(Equal(source.ID))
+			Expect(len(fetched.IDMembers)).To(Equal(2))
+		})
+
+		g.It("should move a card to another board, remapping labels and members", func() {
+			// Labels and members cached on the board are not trusted
+			stale := *target
+			stale.labels = []Label{}
+			stale.Members = []*Member{{ID: "5f0000000000000000000000"}}
+			Expect(card.MoveToBoard(&stale, targetList, MoveCardOptions{Pos: "top"})).To(BeNil())
+			Expect(card.IDBoard).To(Equal(target.ID))
+			Expect(card.IDList).To(Equal(targetList.ID))
+			Expect(len(card.Labels)).To(Equal(1))
+			Expect(card.Labels[0].IDBoard).To(Equal(target.ID))
+			Expect(card.Labels[0].Name).To(Equal("Bug"))
+			Expect(len(card.IDMembers)).To(Equal(1))
+
+			actions, err := target.Actions(NewArgument("filter", string(MoveCardToBoard)))
+			Expect(err).To(BeNil())
+			Expect(len(actions)).To(Equal(1))
+		})
+
+		g.It("should create missing labels when asked", func() {
+			Expect(card.MoveToBoard(source, nil, MoveCardOptions{})).To(BeNil())
+			Expect(card.IDList).To(Equal(sourceList.ID))
+			Expect(len(card.Labels)).To(Equal(1))
+
+			_, err = card.AddNewLabel("Urgent", "orange")
+			Expect(err).To(BeNil())
+			card, err = client.Card(card.ID)
+			Expect(err).To(BeNil())
+			Expect(card.MoveToBoard(target, nil, MoveCardOptions{CreateLabels: true})).To(BeNil())
+			Expect(len(card.Labels)).To(Equal(2))
+			Expect(card.Labels[1].Name).To(Equal("Urgent"))
+			Expect(card.Labels[1].IDBoard).To(Equal(target.ID))
+		})
+
+		g.After(func() {
+			Expect(source.Delete()).To(BeNil())
+			Expect(target.Delete()).To(BeNil())
+		})
+	})
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package trello
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/url"
@@ -38,7 +39,12 @@ type Checklist struct {
 // Delete will delete the checklist
 // https://developers.trello.com/advanced-reference/checklist#delete-1-checklists-idchecklist
 func (c *Checklist) Delete() error {
-	_, err := c.client.Delete("/checklists/" + c.ID)
+	return c.DeleteContext(context.Background())
+}
+
+// DeleteContext will delete the checklist (with context)
+func (c *Checklist) DeleteContext(ctx context.Context) error {
+	_, err := c.client.DeleteContext(ctx, "/checklists/"+c.ID)
 	return err
 }
 
@@ -48,36 +54,103 @@ func (c *Checklist) Delete() error {
 //   pos can take the values 'top', 'bottom', or a positive integer
 // https://developers.trello.com/advanced-reference/checklist#post-1-checklists-idchecklist-checkitems
 func (c *Checklist) AddItem(name string, pos string, checked bool) (checklistItem *ChecklistItem, err error) {
+	return c.AddItemContext(context.Background(), name, pos, checked)
+}
+
+// AddItemContext will add a new item to the given checklist (with context)
+func (c *Checklist) AddItemContext(ctx context.Context, name string, pos string, checked bool) (checklistItem *ChecklistItem, err error) {
 	checklistItem = &ChecklistItem{}
-	payload := url.Values{}
-	if len(name) < 1 || len(name) > 16384 {
-		return nil, fmt.Errorf("Checklist item name %q has invalid length. 1 <= length <= 16384", name)
+	if err = validateChecklistItem(name, pos); err != nil {
+		return nil, err
 	}
+	payload := url.Values{}
 	payload.Set("name", name)
 	if pos != "" {
-		if pos != "top" && pos != "bottom" {
-			i, err := strconv.Atoi(pos)
-			if err != nil {
-				return nil, err
-			}
-			if i < 1 {
-				return nil, fmt.Errorf("Checklist item position %q is invalid. Only 'top', 'bottom', or a positive integer", pos)
-			}
-		}
 		payload.Set("pos", pos)
 	}
 	payload.Set("checked", strconv.FormatBool(checked))
-	body, err := c.client.Post("/checklist/"+c.ID+"/checkItems", payload)
+	body, err := c.client.PostContext(ctx, "/checklist/"+c.ID+"/checkItems", payload)
 	if err == nil {
 		err = parseChecklistItem(body, checklistItem, c.client, c.ID)
+		checklistItem.cardID = c.IDCard
 	}
 	return
 }
 
+// Items - Get the items of a Checklist
+// - https://developer.atlassian.com/cloud/trello/rest/api-group-checklists/#api-checklists-id-checkitems-get
+func (c *Checklist) Items() (items []ChecklistItem, err error) {
+	return c.ItemsContext(context.Background())
+}
+
+// ItemsContext - Get the items of a Checklist (with context)
+func (c *Checklist) ItemsContext(ctx context.Context) (items []ChecklistItem, err error) {
+	body, err := c.client.GetContext(ctx, "/checklists/"+c.ID+"/checkItems")
+	if err == nil {
+		err = json.Unmarshal(body, &items)
+		for i := range items {
+			items[i].client = c.client
+			items[i].listID = c.ID
+			items[i].cardID = c.IDCard
+		}
+	}
+	return
+}
+
+// SetName - Rename a Checklist
+// - https://developer.atlassian.com/cloud/trello/rest/api-group-checklists/#api-checklists-id-field-put
+func (c *Checklist) SetName(name string) (err error) {
+	return c.SetNameContext(context.Background(), name)
+}
+
+// SetNameContext - Rename a Checklist (with context)
+func (c *Checklist) SetNameContext(ctx context.Context, name string) (err error) {
+	return c.update(ctx, "name", name)
+}
+
+// Move - Change the position of a Checklist on its card
+// - https://developer.atlassian.com/cloud/trello/rest/api-group-checklists/#api-checklists-id-field-put
+// pos can be "bottom", "top" or a positive number
+func (c *Checklist) Move(pos string) (err error) {
+	return c.MoveContext(context.Background(), pos)
+}
+
+// MoveContext - Change the position of a Checklist on its card (with context)
+func (c *Checklist) MoveContext(ctx context.Context, pos string) (err error) {
+	return c.update(ctx, "pos", pos)
+}
+
+func (c *Checklist) update(ctx context.Context, field, value string) (err error) {
+	payload := url.Values{}
+	payload.Set("value", value)
+	body, err := c.client.PutContext(ctx, "/checklists/"+c.ID+"/"+field, payload)
+	if err == nil {
+		err = parseChecklist(body, c, c.client)
+	}
+	return
+}
+
+// validateChecklistItem - Check the name and position of a new checklist item
+func validateChecklistItem(name, pos string) error {
+	if len(name) < 1 || len(name) > 16384 {
+		return fmt.Errorf("Checklist item name %q has invalid length. 1 <= length <= 16384", name)
+	}
+	if pos != "" && pos != "top" && pos != "bottom" {
+		i, err := strconv.Atoi(pos)
+		if err != nil {
+			return err
+		}
+		if i < 1 {
+			return fmt.Errorf("Checklist item position %q is invalid. Only 'top', 'bottom', or a positive integer", pos)
+		}
+	}
+	return nil
+}
+
 func parseChecklist(body []byte, checklist *Checklist, client *Client) (err error) {
 	err = json.Unmarshal(body, &checklist)
 	if err == nil {
-		checklist.client = client
+		checklist.setClient(client)
 	}
 	return
 }
@@ -85,13 +158,17 @@ func parseChecklist(body []byte, checklist *Checklist, client *Client) (err erro
 func parseListChecklists(body []byte, client *Client) (checklists []Checklist, err error) {
 	err = json.Unmarshal(body, &checklists)
 	for i := range checklists {
-		list := checklists[i]
-		list.client = client
-		for j := range list.CheckItems {
-			item := list.CheckItems[j]
-			item.client = client
-			item.listID = list.ID
-		}
+		checklists[i].setClient(client)
 	}
 	return
 }
+
+// setClient - Set the client on the checklist and its items
+func (c *Checklist) setClient(client *Client) {
+	c.client = client
+	for i := range c.CheckItems {
+		c.CheckItems[i].client = client
+		c.CheckItems[i].listID = c.ID
+		c.CheckItems[i].cardID = c.IDCard
+	}
+}
@@ -17,26 +17,149 @@ limitations under the License.
 package trello
 
 import (
+	"context"
 	"encoding/json"
+	"net/url"
+	"time"
 )
 
 // ChecklistItem - Trello Checklist Item (member of Checklist)
 type ChecklistItem struct {
 	client   *Client
 	listID   string // back pointer to the parent ID
+	cardID   string // card of the parent checklist
 	State    string `json:"state"`
 	ID       string `json:"id"`
 	Name     string `json:"name"`
 	NameData struct {
 		Emoji struct{} `json:"emoji"`
 	} `json:"nameData"`
-	Pos int `json:"pos"`
+	Pos         float64    `json:"pos"`
+	IDChecklist string     `json:"idChecklist"`
+	Due         *time.Time `json:"due"`
+	IDMember    *string    `json:"idMember"`
+}
+
+// Complete - Whether the item is checked
+func (i *ChecklistItem) Complete() bool {
+	return i.State == "complete"
+}
+
+// SetState - Check (complete) or uncheck a ChecklistItem
+// - https://developer.atlassian.com/cloud/trello/rest/api-group-cards/#api-cards-idcard-checkitem-idcheckitem-put
+func (i *ChecklistItem) SetState(complete bool) (err error) {
+	return i.SetStateContext(context.Background(), complete)
+}
+
+// SetStateContext - Check (complete) or uncheck a ChecklistItem (with context)
+func (i *ChecklistItem) SetStateContext(ctx context.Context, complete bool) (err error) {
+	payload := url.Values{}
+	if complete {
+		payload.Set("state", "complete")
+	} else {
+		payload.Set("state", "incomplete")
+	}
+	return i.update(ctx, payload)
+}
+
+// SetName - Rename a ChecklistItem
+// - https://developer.atlassian.com/cloud/trello/rest/api-group-cards/#api-cards-idcard-checkitem-idcheckitem-put
+func (i *ChecklistItem) SetName(name string) (err error) {
+	return i.SetNameContext(context.Background(), name)
+}
+
+// SetNameContext - Rename a ChecklistItem (with context)
+func (i *ChecklistItem) SetNameContext(ctx context.Context, name string) (err error) {
+	payload := url.Values{}
+	payload.Set("name", name)
+	return i.update(ctx, payload)
+}
+
+// Move - Change the position of a ChecklistItem in its Checklist
+// - https://developer.atlassian.com/cloud/trello/rest/api-group-cards/#api-cards-idcard-checkitem-idcheckitem-put
+// pos can be "bottom", "top" or a positive number
+func (i *ChecklistItem) Move(pos string) (err error) {
+	return i.MoveContext(context.Background(), pos)
+}
+
+// MoveContext - Change the position of a ChecklistItem in its Checklist (with context)
+func (i *ChecklistItem) MoveContext(ctx context.Context, pos string) (err error) {
+	payload := url.Values{}
+	payload.Set("pos", pos)
+	return i.update(ctx, payload)
+}
+
+// SetDue - Set the due date of a ChecklistItem (nil removes it)
+// - https://developer.atlassian.com/cloud/trello/rest/api-group-cards/#api-cards-idcard-checkitem-idcheckitem-put
+func (i *ChecklistItem) SetDue(due *time.Time) (err error) {
+	return i.SetDueContext(context.Background(), due)
+}
+
+// SetDueContext - Set the due date of a ChecklistItem (with context)
+func (i *ChecklistItem) SetDueContext(ctx context.Context, due *time.Time) (err error) {
+	payload := url.Values{}
+	if due != nil {
+		payload.Set("due", due.UTC().Format(time.RFC3339Nano))
+	} else {
+		payload.Set("due", "null")
+	}
+	return i.update(ctx, payload)
+}
+
+// SetMember - Assign a ChecklistItem to a member of the board (nil removes the assignment)
+// - https://developer.atlassian.com/cloud/trello/rest/api-group-cards/#api-cards-idcard-checkitem-idcheckitem-put
+func (i *ChecklistItem) SetMember(member *Member) (err error) {
+	return i.SetMemberContext(context.Background(), member)
+}
+
+// SetMemberContext - Assign a ChecklistItem to a member of the board (with context)
+func (i *ChecklistItem) SetMemberContext(ctx context.Context, member *Member) (err error) {
+	payload := url.Values{}
+	if member != nil {
+		payload.Set("idMember", member.ID)
+	} else {
+		payload.Set("idMember", "null")
+	}
+	return i.update(ctx, payload)
+}
+
+// ConvertToCard - Replace a ChecklistItem by a Card with its name, in the list of the card of the checklist
+// - https://developer.atlassian.com/cloud/trello/rest/api-group-cards/#api-cards-idcard-checklist-idchecklist-checkitem-idcheckitem-converttocard-post
+func (i *ChecklistItem) ConvertToCard() (card *Card, err error) {
+	return i.ConvertToCardContext(context.Background())
+}
+
+// ConvertToCardContext - Replace a ChecklistItem by a Card (with context)
+func (i *ChecklistItem) ConvertToCardContext(ctx context.Context) (card *Card, err error) {
+	card = &Card{}
+	body, err := i.client.PostContext(ctx, "/cards/"+i.cardID+"/checklist/"+i.listID+"/checkItem/"+i.ID+"/convertToCard", url.Values{})
+	if err == nil {
+		err = parseCard(body, card, i.client)
+	}
+	return
+}
+
+func (i *ChecklistItem) update(ctx context.Context, payload url.Values) (err error) {
+	body, err := i.client.PutContext(ctx, "/cards/"+i.cardID+"/checkItem/"+i.ID, payload)
+	if err == nil {
+		updated := ChecklistItem{}
+		if err = parseChecklistItem(body, &updated, i.client, i.listID); err == nil {
+			updated.cardID = i.cardID
+			*i = updated
+		}
+	}
+	return
 }
 
 // Delete - Delete a ChecklistItem from Checklist
 // - https://developer.atlassian.com/cloud/trello/rest/api-group-checklists/#api-checklists-id-checkitems-idcheckitem-delete
 func (i *ChecklistItem) Delete() error {
-	_, err := i.client.Delete("/checklists/" + i.listID + "/checkItems/" + i.ID)
+	return i.DeleteContext(context.Background())
+}
+
+// DeleteContext - Delete a ChecklistItem from Checklist (with context)
+func (i *ChecklistItem) DeleteContext(ctx context.Context) error {
+	_, err := i.client.DeleteContext(ctx, "/checklists/"+i.listID+"/checkItems/"+i.ID)
 	return err
 }
 
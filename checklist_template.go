@@ -0,0 +1,165 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trello
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// ChecklistTemplate - Reusable checklist (a name and items) that can be added to many cards
+//
+// Templates are usually loaded from YAML or JSON, where an item is either its name or an object:
+//
+//	name: Release
+//	items:
+//	  - Tag the release
+//	  - name: Announce it
+//	    pos: top
+//	  - name: Update the changelog
+//	    checked: true
+type ChecklistTemplate struct {
+	Name  string                  `json:"name" yaml:"name"`
+	Items []ChecklistTemplateItem `json:"items" yaml:"items"`
+}
+
+// ChecklistTemplateItem - Item of a ChecklistTemplate
+type ChecklistTemplateItem struct {
+	Name    string `json:"name" yaml:"name"`
+	Checked bool   `json:"checked,omitempty" yaml:"checked,omitempty"`
+	// Pos is "top", "bottom" or a positive integer ("bottom", keeping the order of the template, if empty)
+	Pos string `json:"pos,omitempty" yaml:"pos,omitempty"`
+}
+
+// UnmarshalJSON - Decode an item given as its name or as an object
+func (i *ChecklistTemplateItem) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*i = ChecklistTemplateItem{Name: name}
+		return nil
+	}
+	type item ChecklistTemplateItem
+	return json.Unmarshal(data, (*item)(i))
+}
+
+// UnmarshalYAML - Decode an item given as its name or as a mapping
+func (i *ChecklistTemplateItem) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var name string
+	if err := unmarshal(&name); err == nil {
+		*i = ChecklistTemplateItem{Name: name}
+		return nil
+	}
+	type item ChecklistTemplateItem
+	return unmarshal((*item)(i))
+}
+
+// ParseChecklistTemplate - Decode a ChecklistTemplate from YAML or JSON (JSON being valid YAML)
+func ParseChecklistTemplate(data []byte) (template *ChecklistTemplate, err error) {
+	template = &ChecklistTemplate{}
+	if err = yaml.UnmarshalStrict(data, template); err != nil {
+		return nil, err
+	}
+	if err = template.Validate(); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// LoadChecklistTemplate - Read a ChecklistTemplate from a YAML or JSON file
+func LoadChecklistTemplate(path string) (template *ChecklistTemplate, err error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	template, err = ParseChecklistTemplate(data)
+	if err != nil {
+		return nil, fmt.Errorf("Checklist template %s: %v", path, err)
+	}
+	return
+}
+
+// Validate - Check the template before any request is made (names are required, positions valid)
+func (t *ChecklistTemplate) Validate() error {
+	if t.Name == "" {
+		return fmt.Errorf("Checklist template has no name")
+	}
+	for _, item := range t.Items {
+		if err := validateChecklistItem(item.Name, item.Pos); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddTo - Add the checklist described by the template to a Card
+// - https://developer.atlassian.com/cloud/trello/rest/api-group-checklists/#api-checklists-id-checkitems-post
+func (t *ChecklistTemplate) AddTo(card *Card) (checklist *Checklist, err error) {
+	return t.AddToContext(context.Background(), card)
+}
+
+// AddToContext - Add the checklist described by the template to a Card (with context)
+// This takes a request for the checklist and one per item; see StampContext for many cards.
+func (t *ChecklistTemplate) AddToContext(ctx context.Context, card *Card) (checklist *Checklist, err error) {
+	if err = t.Validate(); err != nil {
+		return nil, err
+	}
+	checklist, err = card.AddChecklistContext(ctx, t.Name)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range t.Items {
+		added, err := checklist.AddItemContext(ctx, item.Name, item.Pos, item.Checked)
+		if err != nil {
+			return checklist, err
+		}
+		checklist.CheckItems = append(checklist.CheckItems, *added)
+	}
+	sort.SliceStable(checklist.CheckItems, func(i, j int) bool { return checklist.CheckItems[i].Pos < checklist.CheckItems[j].Pos })
+	return
+}
+
+// Stamp - Add the checklist described by the template to many cards
+// The checklist is built item by item on the first card and copied onto the others,
+// so each other card takes a single request.
+func (t *ChecklistTemplate) Stamp(cards ...*Card) (checklists []*Checklist, err error) {
+	return t.StampContext(context.Background(), cards...)
+}
+
+// StampContext - Add the checklist described by the template to many cards (with context)
+// The checklists created before an error are returned with it.
+func (t *ChecklistTemplate) StampContext(ctx context.Context, cards ...*Card) (checklists []*Checklist, err error) {
+	for i, card := range cards {
+		var checklist *Checklist
+		if i == 0 {
+			checklist, err = t.AddToContext(ctx, card)
+		} else {
+			checklist, err = card.AddChecklistFromContext(ctx, checklists[0])
+		}
+		if checklist != nil && checklist.ID != "" {
+			checklists = append(checklists, checklist)
+		}
+		if err != nil {
+			return checklists, err
+		}
+	}
+	return
+}
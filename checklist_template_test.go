@@ -0,0 +1,129 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trello
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	goblin "github.com/franela/goblin"
+	. "github.com/onsi/gomega"
+)
+
+const releaseTemplate = `
+name: Release
+items:
+  - Tag the release
+  - name: Update the changelog
+    checked: true
+  - name: Freeze the branch
+    pos: top
+`
+
+func TestChecklistTemplates(t *testing.T) {
+	g := goblin.Goblin(t)
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+
+	g.Describe("Checklist template tests", func() {
+		var board *Board
+		var cards []*Card
+
+		g.Before(func() {
+			board, err = client.CreateBoard(testName("GoTestTrello-ChecklistTemplates"))
+			Expect(err).To(BeNil())
+			lists, err := board.Lists()
+			Expect(err).To(BeNil())
+			for _, name := range []string{"v1.0", "v1.1", "v1.2"} {
+				card, err := lists[0].AddCard(Card{Name: name})
+				Expect(err).To(BeNil())
+				cards = append(cards, card)
+			}
+		})
+
+		g.It("should parse templates from YAML and JSON", func() {
+			template, err := ParseChecklistTemplate([]byte(releaseTemplate))
+			Expect(err).To(BeNil())
+			Expect(template.Name).To(Equal("Release"))
+			Expect(template.Items).To(Equal([]ChecklistTemplateItem{
+				{Name: "Tag the release"},
+				{Name: "Update the changelog", Checked: true},
+				{Name: "Freeze the branch", Pos: "top"},
+			}))
+
+			fromJSON, err := ParseChecklistTemplate([]byte(`{"name": "Release", "items": ["Tag the release",
+				{"name": "Update the changelog", "checked": true}, {"name": "Freeze the branch", "pos": "top"}]}`))
+			Expect(err).To(BeNil())
+			Expect(fromJSON).To(Equal(template))
+
+			dir, err := ioutil.TempDir("", "go-trello")
+			Expect(err).To(BeNil())
+			defer os.RemoveAll(dir)
+			path := filepath.Join(dir, "release.yaml")
+			Expect(ioutil.WriteFile(path, []byte(releaseTemplate), 0600)).To(BeNil())
+			loaded, err := LoadChecklistTemplate(path)
+			Expect(err).To(BeNil())
+			Expect(loaded).To(Equal(template))
+		})
+
+		g.It("should reject invalid templates", func() {
+			_, err := ParseChecklistTemplate([]byte("items: [Orphan]"))
+			Expect(err).NotTo(BeNil())
+			_, err = ParseChecklistTemplate([]byte("name: Release\nitems: [{name: Middle, pos: middle}]"))
+			Expect(err).NotTo(BeNil())
+			_, err = ParseChecklistTemplate([]byte("name: Release\ntasks: [Typo]"))
+			Expect(err).NotTo(BeNil())
+			_, err = LoadChecklistTemplate(filepath.Join(os.TempDir(), "go-trello-missing.yaml"))
+			Expect(err).NotTo(BeNil())
+		})
+
+		g.It("should copy a checklist to another card", func() {
+			source, err := cards[0].AddChecklist("Source")
+			Expect(err).To(BeNil())
+			_, err = source.AddItem("Only item", "bottom", true)
+			Expect(err).To(BeNil())
+
+			copied, err := cards[1].AddChecklistFrom(source)
+			Expect(err).To(BeNil())
+			Expect(copied.ID).NotTo(Equal(source.ID))
+			Expect(copied.Name).To(Equal("Source"))
+			Expect(copied.IDCard).To(Equal(cards[1].ID))
+			Expect(len(copied.CheckItems)).To(Equal(1))
+			Expect(copied.CheckItems[0].Complete()).To(BeTrue())
+		})
+
+		g.It("should stamp a template onto many cards", func() {
+			template, err := ParseChecklistTemplate([]byte(releaseTemplate))
+			Expect(err).To(BeNil())
+			checklists, err := template.Stamp(cards...)
+			Expect(err).To(BeNil())
+			Expect(len(checklists)).To(Equal(3))
+			for i, checklist := range checklists {
+				Expect(checklist.IDCard).To(Equal(cards[i].ID))
+				Expect(checklist.Name).To(Equal("Release"))
+				Expect(len(checklist.CheckItems)).To(Equal(3))
+				Expect(checklist.CheckItems[0].Name).To(Equal("Freeze the branch"))
+				Expect(checklist.CheckItems[2].Complete()).To(BeTrue())
+			}
+		})
+
+		g.After(func() {
+			Expect(board.Delete()).To(BeNil())
+		})
+	})
+}
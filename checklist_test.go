@@ -17,7 +17,6 @@ limitations under the License.
 package trello
 
 import (
-	"fmt"
 	"log"
 	"strings"
 	"testing"
@@ -39,7 +38,7 @@ func TestChecklist(t *testing.T) {
 
 		// Prerequisites
 		g.Before(func() {
-			testBoardName = fmt.Sprintf("GoTestTrello-Checklist-%v", time.Now().Unix())
+			testBoardName = testName("GoTestTrello-Checklist")
 			board, err = client.CreateBoard(testBoardName)
 			if err != nil {
 				log.Fatal("ERROR Creating Board: " + err.Error())
@@ -103,6 +102,52 @@ func TestChecklist(t *testing.T) {
 			Expect(checklistItem.Name).To(Equal("Test Item"))
 		})
 
+		g.It("should check and uncheck an item", func() {
+			Expect(checklistItem.SetState(true)).To(BeNil())
+			Expect(checklistItem.Complete()).To(BeTrue())
+			Expect(checklistItem.SetState(false)).To(BeNil())
+			Expect(checklistItem.State).To(Equal("incomplete"))
+		})
+
+		g.It("should rename and reorder items", func() {
+			Expect(checklistItem.SetName("Renamed Item")).To(BeNil())
+			Expect(checklistItem.Name).To(Equal("Renamed Item"))
+
+			first, err := checklist.AddItem("First Item", "top", false)
+			Expect(err).To(BeNil())
+			Expect(checklistItem.Move("top")).To(BeNil())
+			Expect(checklistItem.Pos).To(BeNumerically("<", first.Pos))
+
+			items, err := checklist.Items()
+			Expect(err).To(BeNil())
+			Expect(len(items)).To(Equal(2))
+			Expect(items[0].ID).To(Equal(checklistItem.ID))
+			Expect(items[1].Name).To(Equal("First Item"))
+			Expect(items[1].Delete()).To(BeNil())
+		})
+
+		g.It("should set the due date and member of an item", func() {
+			me, err := client.Member("me")
+			Expect(err).To(BeNil())
+			due := time.Date(2030, 2, 3, 10, 0, 0, 0, time.UTC)
+			Expect(checklistItem.SetDue(&due)).To(BeNil())
+			Expect(checklistItem.Due.Equal(due)).To(BeTrue())
+			Expect(checklistItem.SetMember(me)).To(BeNil())
+			Expect(*checklistItem.IDMember).To(Equal(me.ID))
+
+			Expect(checklistItem.SetDue(nil)).To(BeNil())
+			Expect(checklistItem.Due).To(BeNil())
+			Expect(checklistItem.SetMember(nil)).To(BeNil())
+			Expect(checklistItem.IDMember).To(BeNil())
+		})
+
+		g.It("should rename and move a checklist", func() {
+			Expect(checklist.SetName("Release")).To(BeNil())
+			Expect(checklist.Name).To(Equal("Release"))
+			Expect(checklist.Move("bottom")).To(BeNil())
+			Expect(checklist.CheckItems[0].cardID).To(Equal(checklist.IDCard))
+		})
+
 		// Add this board test here since it gets Checklists
 		g.It("should get the checklists in a board", func() {
 			_, err := board.Checklists()
@@ -131,3 +176,68 @@ func TestChecklist(t *testing.T) {
 	})
 
 }
+
+func TestChecklistConversions(t *testing.T) {
+	g := goblin.Goblin(t)
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+
+	g.Describe("Checklist conversion tests", func() {
+		var board *Board
+		var list *List
+		var card *Card
+		var checklist *Checklist
+
+		g.Before(func() {
+			board, err = client.CreateBoard(testName("GoTestTrello-Conversions"))
+			Expect(err).To(BeNil())
+			lists, err := board.Lists()
+			Expect(err).To(BeNil())
+			list = &lists[0]
+			card, err = list.AddCard(Card{Name: "Triage"})
+			Expect(err).To(BeNil())
+			checklist, err = card.AddChecklist("Follow-ups")
+			Expect(err).To(BeNil())
+		})
+
+		g.It("should convert a checklist item to a card", func() {
+			item, err := checklist.AddItem("Grown too big", "bottom", false)
+			Expect(err).To(BeNil())
+			due := time.Date(2030, 4, 5, 0, 0, 0, 0, time.UTC)
+			Expect(item.SetDue(&due)).To(BeNil())
+
+			converted, err := item.ConvertToCard()
+			Expect(err).To(BeNil())
+			Expect(converted.Name).To(Equal("Grown too big"))
+			Expect(converted.IDList).To(Equal(list.ID))
+			Expect(converted.Due.Equal(due)).To(BeTrue())
+
+			items, err := checklist.Items()
+			Expect(err).To(BeNil())
+			Expect(items).To(BeEmpty())
+			actions, err := converted.Actions(NewArgument("filter", string(ConvertToCardFromCheckItem)))
+			Expect(err).To(BeNil())
+			Expect(len(actions)).To(Equal(1))
+			Expect(actions[0].Data.CardSource.ID).To(Equal(card.ID))
+		})
+
+		g.It("should turn a card into a checklist item", func() {
+			due := time.Date(2030, 5, 6, 0, 0, 0, 0, time.UTC)
+			small, err := list.AddCard(Card{Name: "Small enough", Due: &due})
+			Expect(err).To(BeNil())
+
+			item, err := small.ToChecklistItem(checklist)
+			Expect(err).To(BeNil())
+			Expect(item.Name).To(Equal("Small enough " + small.ShortURL))
+			Expect(item.Due.Equal(due)).To(BeTrue())
+			Expect(small.Closed).To(BeTrue())
+
+			fetched, err := client.Card(small.ID)
+			Expect(err).To(BeNil())
+			Expect(fetched.Closed).To(BeTrue())
+		})
+
+		g.After(func() {
+			Expect(board.Delete()).To(BeNil())
+		})
+	})
+}
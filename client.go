@@ -17,18 +17,32 @@ limitations under the License.
 package trello
 
 import (
-	"fmt"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
 	"io/ioutil"
+	"mime"
+	"mime/multipart"
 	"net/http"
+	"net/textproto"
 	"net/url"
+	"sort"
 	"strings"
+	"time"
 )
 
 // Client - Trello Client Type
 type Client struct {
-	client   *http.Client
-	endpoint string
-	version  string
+	client      *http.Client
+	endpoint    string
+	version     string
+	userAgent   string
+	logger      Logger
+	rateLimiter *RateLimiter
+	retryPolicy *RetryPolicy
+	key         string
+	token       *string
 }
 
 // Version - Trello API Version
@@ -36,14 +50,57 @@ func (c *Client) Version() string {
 	return c.version
 }
 
+// SetRateLimiter - Throttle all requests made by the client (nil disables rate limiting)
+func (c *Client) SetRateLimiter(limiter *RateLimiter) {
+	c.rateLimiter = limiter
+}
+
+// RateLimiter - Return the client rate limiter (nil if rate limiting is disabled)
+func (c *Client) RateLimiter() *RateLimiter {
+	return c.rateLimiter
+}
+
+// SetRetryPolicy - Retry failed requests according to policy (nil disables retries)
+func (c *Client) SetRetryPolicy(policy *RetryPolicy) {
+	c.retryPolicy = policy
+}
+
+// RetryPolicy - Return the client retry policy (nil if retries are disabled)
+func (c *Client) RetryPolicy() *RetryPolicy {
+	return c.retryPolicy
+}
+
 func (c *Client) do(req *http.Request) (body []byte, err error) {
-	resp, err := c.client.Do(req)
+	resp, err := c.doStream(req)
 	if err == nil {
 		defer resp.Body.Close()
 		body, err = ioutil.ReadAll(resp.Body)
-		if err == nil {
-			if resp.StatusCode != 200 {
-				err = fmt.Errorf("Received unexpected status %d while trying to retrieve the server data with \"%s\"", resp.StatusCode, string(body))
+	}
+	return
+}
+
+// doStream - Send the request, retrying according to the retry policy, and
+// return the successful response with its body left for the caller to read and close
+func (c *Client) doStream(req *http.Request) (resp *http.Response, err error) {
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+	for attempt := 1; ; attempt++ {
+		resp, err = c.send(req)
+		delay, retry := c.retryPolicy.next(req, resp, err, attempt)
+		if !retry {
+			break
+		}
+		c.logf("trello: retrying %s %s in %v (attempt %d of %d)", req.Method, c.resource(req), delay, attempt+1, c.retryPolicy.MaxAttempts)
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+		if req.GetBody != nil {
+			if req.Body, err = req.GetBody(); err != nil {
 				return nil, err
 			}
 		}
@@ -51,9 +108,53 @@ func (c *Client) do(req *http.Request) (body []byte, err error) {
 	return
 }
 
+// send - Perform a single attempt of the request
+// The body of an error response is read into the returned APIError and closed.
+func (c *Client) send(req *http.Request) (resp *http.Response, err error) {
+	if c.rateLimiter != nil {
+		if err = c.rateLimiter.Wait(req.Context()); err != nil {
+			return
+		}
+	}
+	start := time.Now()
+	resp, err = c.client.Do(req)
+	if err != nil {
+		c.logf("trello: %s %s failed: %v", req.Method, c.resource(req), err)
+		return
+	}
+	c.logf("trello: %s %s %d (%v)", req.Method, c.resource(req), resp.StatusCode, time.Since(start))
+	if c.rateLimiter != nil {
+		c.rateLimiter.Update(resp)
+	}
+	if resp.StatusCode != 200 {
+		defer resp.Body.Close()
+		var body []byte
+		if body, err = ioutil.ReadAll(resp.Body); err == nil {
+			err = newAPIError(c.resource(req), req, resp, body)
+		}
+	}
+	return
+}
+
+// resource - Request URL relative to the endpoint, without credentials
+func (c *Client) resource(req *http.Request) string {
+	return strings.TrimPrefix(scrubURL(req.URL), c.endpoint)
+}
+
+func (c *Client) logf(format string, v ...interface{}) {
+	if c.logger != nil {
+		c.logger.Printf(format, v...)
+	}
+}
+
 // Get - HTTP GET
 func (c *Client) Get(resource string) (body []byte, err error) {
-	req, err := http.NewRequest("GET", c.endpoint+resource, nil)
+	return c.GetContext(context.Background(), resource)
+}
+
+// GetContext - HTTP GET (with context)
+func (c *Client) GetContext(ctx context.Context, resource string) (body []byte, err error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.endpoint+resource, nil)
 	if err == nil {
 		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 		body, err = c.do(req)
@@ -63,7 +164,12 @@ func (c *Client) Get(resource string) (body []byte, err error) {
 
 // Post - HTTP POST
 func (c *Client) Post(resource string, data url.Values) (body []byte, err error) {
-	req, err := http.NewRequest("POST", c.endpoint+resource, strings.NewReader(data.Encode()))
+	return c.PostContext(context.Background(), resource, data)
+}
+
+// PostContext - HTTP POST (with context)
+func (c *Client) PostContext(ctx context.Context, resource string, data url.Values) (body []byte, err error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", c.endpoint+resource, strings.NewReader(data.Encode()))
 	if err == nil {
 		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 		body, err = c.do(req)
@@ -71,9 +177,88 @@ func (c *Client) Post(resource string, data url.Values) (body []byte, err error)
 	return
 }
 
+// postStream - HTTP POST of a body of contentType read as it is sent
+func (c *Client) postStream(ctx context.Context, resource, contentType string, data io.Reader) (body []byte, err error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", c.endpoint+resource, data)
+	if err == nil {
+		req.Header.Set("Content-Type", contentType)
+		body, err = c.do(req)
+	}
+	return
+}
+
+// postMultipart - HTTP POST of a multipart form with the fields and a single file
+// The file is streamed rather than buffered, so uploads may be larger than memory.
+func (c *Client) postMultipart(ctx context.Context, resource string, fields url.Values, fileField, fileName string, file io.Reader, mimeType string) (body []byte, err error) {
+	pr, pw := io.Pipe()
+	form := multipart.NewWriter(pw)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		pw.CloseWithError(writeMultipart(form, fields, fileField, fileName, file, mimeType))
+	}()
+
+	body, err = c.postStream(ctx, resource, form.FormDataContentType(), pr)
+	// Stop the writer if the request ended early, and only return once file is no longer read
+	pr.Close()
+	<-done
+	return
+}
+
+// writeMultipart - Write the fields and the file of an upload, then close the form
+func writeMultipart(form *multipart.Writer, fields url.Values, fileField, fileName string, file io.Reader, mimeType string) (err error) {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		for _, value := range fields[key] {
+			if err = form.WriteField(key, value); err != nil {
+				return
+			}
+		}
+	}
+	if mimeType == "" {
+		mimeType = "application/octet-stream"
+	} else if err = form.WriteField("mimeType", mimeType); err != nil {
+		return
+	}
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", mime.FormatMediaType("form-data", map[string]string{"name": fileField, "filename": fileName}))
+	header.Set("Content-Type", mimeType)
+	part, err := form.CreatePart(header)
+	if err == nil {
+		_, err = io.Copy(part, file)
+	}
+	if err == nil {
+		err = form.Close()
+	}
+	return
+}
+
+// sendJSON - HTTP request with v encoded as a JSON body (for parameters nested in objects)
+func (c *Client) sendJSON(ctx context.Context, method, resource string, v interface{}) (body []byte, err error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint+resource, bytes.NewReader(data))
+	if err == nil {
+		req.Header.Set("Content-Type", "application/json")
+		body, err = c.do(req)
+	}
+	return
+}
+
 // Put - HTTP PUT
 func (c *Client) Put(resource string, data url.Values) (body []byte, err error) {
-	req, err := http.NewRequest("PUT", c.endpoint+resource, strings.NewReader(data.Encode()))
+	return c.PutContext(context.Background(), resource, data)
+}
+
+// PutContext - HTTP PUT (with context)
+func (c *Client) PutContext(ctx context.Context, resource string, data url.Values) (body []byte, err error) {
+	req, err := http.NewRequestWithContext(ctx, "PUT", c.endpoint+resource, strings.NewReader(data.Encode()))
 	if err == nil {
 		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 		body, err = c.do(req)
@@ -83,7 +268,12 @@ func (c *Client) Put(resource string, data url.Values) (body []byte, err error)
 
 // Delete - HTTP DELETE
 func (c *Client) Delete(resource string) (body []byte, err error) {
-	req, err := http.NewRequest("DELETE", c.endpoint+resource, nil)
+	return c.DeleteContext(context.Background(), resource)
+}
+
+// DeleteContext - HTTP DELETE (with context)
+func (c *Client) DeleteContext(ctx context.Context, resource string) (body []byte, err error) {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", c.endpoint+resource, nil)
 	if err == nil {
 		body, err = c.do(req)
 	}
@@ -99,14 +289,17 @@ type bearerRoundTripper struct {
 
 // RoundTrip encodes key and token as a delegate
 func (b *bearerRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
-	if b.Delegate == nil {
-		b.Delegate = http.DefaultTransport
+	delegate := b.Delegate
+	if delegate == nil {
+		delegate = http.DefaultTransport
 	}
+	// RoundTrippers must not modify the request, so add the credentials to a clone
+	req = req.Clone(req.Context())
 	values := req.URL.Query()
 	values.Add("key", b.key)
 	values.Add("token", *b.token)
 	req.URL.RawQuery = values.Encode()
-	return b.Delegate.RoundTrip(req)
+	return delegate.RoundTrip(req)
 }
 
 // newBearerTokenTransport will return an http.RoundTripper which will add the
@@ -122,32 +315,50 @@ func newBearerTokenTransport(applicationKey string, token *string) *bearerRoundT
 	}
 }
 
-// NewCustomClient can be used to implement your own client
-func NewCustomClient(client *http.Client) (*Client, error) {
-	version := "1"
-	endpoint := "https://api.trello.com/" + version
+// NewClient returns a client needed to make trello API calls, configured by opts.
+// Without WithAuth all API calls will be unauthenticated.
+func NewClient(opts ...Option) (*Client, error) {
+	cfg := &clientConfig{
+		httpClient: http.DefaultClient,
+		baseURL:    DefaultBaseURL,
+	}
+	for _, opt := range opts {
+		if err := opt(cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	client := cfg.httpClient
+	if cfg.token != nil {
+		// Copy the http.Client so the caller's (or the default) client is left untouched
+		authClient := *cfg.httpClient
+		rr := newBearerTokenTransport(cfg.key, cfg.token)
+		rr.Delegate = authClient.Transport
+		authClient.Transport = rr
+		client = &authClient
+	}
 
 	return &Client{
-		client:   client,
-		endpoint: endpoint,
-		version:  version,
+		client:      client,
+		endpoint:    cfg.baseURL,
+		version:     "1",
+		userAgent:   cfg.userAgent,
+		logger:      cfg.logger,
+		rateLimiter: cfg.rateLimiter,
+		retryPolicy: cfg.retryPolicy,
+		key:         cfg.key,
+		token:       cfg.token,
 	}, nil
 }
 
+// NewCustomClient can be used to implement your own client
+func NewCustomClient(client *http.Client) (*Client, error) {
+	return NewClient(WithHTTPClient(client))
+}
+
 // NewAuthClient will create a trello client which allows authentication. It uses
 // NewBearerTokenTransport to create an http.Client which can be used as a trello
 // client.
 func NewAuthClient(applicationKey string, token *string) (*Client, error) {
-	rr := newBearerTokenTransport(applicationKey, token)
-	client := &http.Client{
-		Transport: rr,
-	}
-	return NewCustomClient(client)
-}
-
-// NewClient returns a client needed to make trello API calls. If transport is nil
-// all API calls will be unauthenticated. If you have a bearer token, NewBearerTokenTransport()
-// may be helpful in making calls authenticated.
-func NewClient() (*Client, error) {
-	return NewCustomClient(http.DefaultClient)
+	return NewClient(WithAuth(applicationKey, token))
 }
@@ -17,11 +17,14 @@ limitations under the License.
 package trello
 
 import (
-	"fmt"
+	"bytes"
+	"context"
+	"errors"
+	"log"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
-	"time"
 
 	goblin "github.com/franela/goblin"
 	. "github.com/onsi/gomega"
@@ -36,7 +39,7 @@ func TestClient(t *testing.T) {
 		var testBoardName string
 
 		g.Before(func() {
-			testBoardName = fmt.Sprintf("GoTestTrello-Board-%v", time.Now().Unix())
+			testBoardName = testName("GoTestTrello-Board")
 		})
 
 		g.It("should create a default client", func() {
@@ -56,11 +59,73 @@ func TestClient(t *testing.T) {
 			Expect(err).To(BeNil())
 		})
 
+		g.It("NewClient should apply the functional options", func() {
+			var received *http.Request
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received = r
+				w.Write([]byte(`{"id":"abc","name":"stub"}`))
+			}))
+			defer server.Close()
+
+			var logged bytes.Buffer
+			token := "secret-token"
+			c, err := NewClient(
+				WithBaseURL(server.URL+"/1/"),
+				WithHTTPClient(server.Client()),
+				WithUserAgent("go-trello-test"),
+				WithAuth("secret-key", &token),
+				WithLogger(log.New(&logged, "", 0)),
+				WithRetryPolicy(NewDefaultRetryPolicy()),
+				WithRateLimiter(NewDefaultRateLimiter()),
+			)
+			Expect(err).To(BeNil())
+			Expect(c.RetryPolicy()).NotTo(BeNil())
+			Expect(c.RateLimiter()).NotTo(BeNil())
+			Expect(server.Client().Transport).NotTo(BeAssignableToTypeOf(&bearerRoundTripper{}))
+
+			b, err := c.Board("abc")
+			Expect(err).To(BeNil())
+			Expect(b.Name).To(Equal("stub"))
+			Expect(received.URL.Path).To(Equal("/1/boards/abc"))
+			Expect(received.URL.Query().Get("key")).To(Equal("secret-key"))
+			Expect(received.URL.Query().Get("token")).To(Equal("secret-token"))
+			Expect(received.Header.Get("User-Agent")).To(Equal("go-trello-test"))
+			Expect(logged.String()).To(ContainSubstring("GET /boards/abc 200"))
+			Expect(logged.String()).NotTo(ContainSubstring("secret"))
+		})
+
+		g.It("NewClient should reject an invalid base URL", func() {
+			_, err = NewClient(WithBaseURL("not a url"))
+			Expect(err).NotTo(BeNil())
+		})
+
 		g.It("should return version", func() {
 			ver := client.Version()
 			Expect(ver).To(Equal("1"))
 		})
 
+		g.It("should fail when the context is cancelled", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			_, err = client.MemberContext(ctx, "me")
+			Expect(err).NotTo(BeNil())
+			Expect(errors.Is(err, context.Canceled)).To(BeTrue())
+		})
+
+		g.It("should return an APIError matching ErrNotFound for a missing board", func() {
+			_, err = client.Board("5f0000000000000000000000")
+			Expect(err).NotTo(BeNil())
+			Expect(errors.Is(err, ErrNotFound)).To(BeTrue())
+			Expect(errors.Is(err, ErrUnauthorized)).To(BeFalse())
+			var apiErr *APIError
+			Expect(errors.As(err, &apiErr)).To(BeTrue())
+			Expect(apiErr.StatusCode).To(Equal(http.StatusNotFound))
+			Expect(apiErr.Method).To(Equal("GET"))
+			Expect(apiErr.Resource).To(Equal("/boards/5f0000000000000000000000"))
+			Expect(apiErr.Error()).NotTo(ContainSubstring("token="))
+			Expect(apiErr.Error()).NotTo(ContainSubstring("key="))
+		})
+
 		g.It("should create a board", func() {
 			board, err = client.CreateBoard(testBoardName)
 			Expect(err).To(BeNil())
@@ -0,0 +1,435 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trello
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// CustomFieldType - Type of the values of a CustomField
+type CustomFieldType string
+
+// Custom field types
+const (
+	CustomFieldText     CustomFieldType = "text"
+	CustomFieldNumber   CustomFieldType = "number"
+	CustomFieldDate     CustomFieldType = "date"
+	CustomFieldCheckbox CustomFieldType = "checkbox"
+	CustomFieldList     CustomFieldType = "list"
+)
+
+// CustomField - Definition of a custom field on a board
+// - https://developer.atlassian.com/cloud/trello/rest/api-group-customfields/
+type CustomField struct {
+	client     *Client
+	ID         string          `json:"id"`
+	IDModel    string          `json:"idModel"`
+	ModelType  string          `json:"modelType"`
+	FieldGroup string          `json:"fieldGroup"`
+	Name       string          `json:"name"`
+	Pos        float64         `json:"pos"`
+	Type       CustomFieldType `json:"type"`
+	Display    struct {
+		CardFront bool `json:"cardFront"`
+	} `json:"display"`
+	// Options of a dropdown (CustomFieldList) field
+	Options []CustomFieldOption `json:"options"`
+}
+
+// CustomFieldOption - Option of a dropdown (CustomFieldList) field
+type CustomFieldOption struct {
+	client        *Client
+	ID            string `json:"id"`
+	IDCustomField string `json:"idCustomField"`
+	Value         struct {
+		Text string `json:"text"`
+	} `json:"value"`
+	Color string  `json:"color"`
+	Pos   float64 `json:"pos"`
+}
+
+// CustomFieldItem - Value of a custom field on a card
+// Value is set for text, number, date and checkbox fields; IDValue is the option of a dropdown field.
+// - https://developer.atlassian.com/cloud/trello/rest/api-group-customfielditems/
+type CustomFieldItem struct {
+	ID            string            `json:"id"`
+	IDCustomField string            `json:"idCustomField"`
+	IDModel       string            `json:"idModel"`
+	ModelType     string            `json:"modelType"`
+	IDValue       string            `json:"idValue,omitempty"`
+	Value         *CustomFieldValue `json:"value,omitempty"`
+}
+
+// CustomFieldValue - Value of a text, number, date or checkbox field
+// Trello encodes every value as a string; the field matching the type of the custom field is set.
+type CustomFieldValue struct {
+	Text    *string
+	Number  *float64
+	Date    *time.Time
+	Checked *bool
+}
+
+// MarshalJSON - Encode the value the way Trello expects it ({"number": "42"}...)
+func (v CustomFieldValue) MarshalJSON() ([]byte, error) {
+	encoded := map[string]string{}
+	if v.Text != nil {
+		encoded["text"] = *v.Text
+	}
+	if v.Number != nil {
+		encoded["number"] = strconv.FormatFloat(*v.Number, 'f', -1, 64)
+	}
+	if v.Date != nil {
+		encoded["date"] = v.Date.UTC().Format(time.RFC3339Nano)
+	}
+	if v.Checked != nil {
+		encoded["checked"] = strconv.FormatBool(*v.Checked)
+	}
+	return json.Marshal(encoded)
+}
+
+// UnmarshalJSON - Decode the string encoded value into Go types
+func (v *CustomFieldValue) UnmarshalJSON(data []byte) (err error) {
+	var encoded map[string]string
+	if err = json.Unmarshal(data, &encoded); err != nil {
+		return
+	}
+	*v = CustomFieldValue{}
+	if text, ok := encoded["text"]; ok {
+		v.Text = &text
+	}
+	if number, ok := encoded["number"]; ok {
+		n, err := strconv.ParseFloat(number, 64)
+		if err != nil {
+			return fmt.Errorf("invalid custom field number %q: %v", number, err)
+		}
+		v.Number = &n
+	}
+	if date, ok := encoded["date"]; ok {
+		d, err := time.Parse(time.RFC3339Nano, date)
+		if err != nil {
+			return fmt.Errorf("invalid custom field date %q: %v", date, err)
+		}
+		v.Date = &d
+	}
+	if checked, ok := encoded["checked"]; ok {
+		c, err := strconv.ParseBool(checked)
+		if err != nil {
+			return fmt.Errorf("invalid custom field checkbox %q: %v", checked, err)
+		}
+		v.Checked = &c
+	}
+	return
+}
+
+// CustomFields - Get the Custom Fields of a Board
+// - https://developer.atlassian.com/cloud/trello/rest/api-group-boards/#api-boards-id-customfields-get
+func (b *Board) CustomFields() (fields []CustomField, err error) {
+	return b.CustomFieldsContext(context.Background())
+}
+
+// CustomFieldsContext - Get the Custom Fields of a Board (with context)
+func (b *Board) CustomFieldsContext(ctx context.Context) (fields []CustomField, err error) {
+	body, err := b.client.GetContext(ctx, "/boards/"+b.ID+"/customFields")
+	if err == nil {
+		fields, err = parseListCustomFields(body, b.client)
+	}
+	return
+}
+
+// AddCustomField - Create a Custom Field on a Board
+// Name and Type are required; Pos (bottom if zero), Display.CardFront and the text and color
+// of the Options of a dropdown field are used.
+// - https://developer.atlassian.com/cloud/trello/rest/api-group-customfields/#api-customfields-post
+func (b *Board) AddCustomField(opts CustomField) (field *CustomField, err error) {
+	return b.AddCustomFieldContext(context.Background(), opts)
+}
+
+// AddCustomFieldContext - Create a Custom Field on a Board (with context)
+func (b *Board) AddCustomFieldContext(ctx context.Context, opts CustomField) (field *CustomField, err error) {
+	field = &CustomField{}
+	payload := map[string]interface{}{
+		"idModel":           b.ID,
+		"modelType":         "board",
+		"name":              opts.Name,
+		"type":              opts.Type,
+		"pos":               "bottom",
+		"display_cardFront": opts.Display.CardFront,
+	}
+	if opts.Pos != 0 {
+		payload["pos"] = opts.Pos
+	}
+	if opts.Type == CustomFieldList {
+		options := []interface{}{}
+		for i, option := range opts.Options {
+			options = append(options, map[string]interface{}{
+				"value": map[string]string{"text": option.Value.Text},
+				"color": optionColor(option.Color),
+				"pos":   (i + 1) * 1024,
+			})
+		}
+		payload["options"] = options
+	}
+	body, err := b.client.sendJSON(ctx, http.MethodPost, "/customFields", payload)
+	if err == nil {
+		err = parseCustomField(body, field, b.client)
+	}
+	return
+}
+
+// CustomField - Get a Custom Field by ID
+// - https://developer.atlassian.com/cloud/trello/rest/api-group-customfields/#api-customfields-id-get
+func (c *Client) CustomField(fieldID string) (field *CustomField, err error) {
+	return c.CustomFieldContext(context.Background(), fieldID)
+}
+
+// CustomFieldContext - Get a Custom Field by ID (with context)
+func (c *Client) CustomFieldContext(ctx context.Context, fieldID string) (field *CustomField, err error) {
+	field = &CustomField{}
+	body, err := c.GetContext(ctx, "/customFields/"+fieldID)
+	if err == nil {
+		err = parseCustomField(body, field, c)
+	}
+	return
+}
+
+// SetName - Rename a Custom Field
+// - https://developer.atlassian.com/cloud/trello/rest/api-group-customfields/#api-customfields-id-put
+func (f *CustomField) SetName(name string) (err error) {
+	return f.SetNameContext(context.Background(), name)
+}
+
+// SetNameContext - Rename a Custom Field (with context)
+func (f *CustomField) SetNameContext(ctx context.Context, name string) (err error) {
+	payload := url.Values{}
+	payload.Set("name", name)
+	return f.update(ctx, payload)
+}
+
+// SetCardFront - Show (or hide) the value of a Custom Field on the front of cards
+// - https://developer.atlassian.com/cloud/trello/rest/api-group-customfields/#api-customfields-id-put
+func (f *CustomField) SetCardFront(show bool) (err error) {
+	return f.SetCardFrontContext(context.Background(), show)
+}
+
+// SetCardFrontContext - Show (or hide) the value of a Custom Field on the front of cards (with context)
+func (f *CustomField) SetCardFrontContext(ctx context.Context, show bool) (err error) {
+	payload := url.Values{}
+	payload.Set("display/cardFront", strconv.FormatBool(show))
+	return f.update(ctx, payload)
+}
+
+// Move - Change the position of a Custom Field
+// - https://developer.atlassian.com/cloud/trello/rest/api-group-customfields/#api-customfields-id-put
+// pos can be "bottom", "top" or a positive number
+func (f *CustomField) Move(pos string) (err error) {
+	return f.MoveContext(context.Background(), pos)
+}
+
+// MoveContext - Change the position of a Custom Field (with context)
+func (f *CustomField) MoveContext(ctx context.Context, pos string) (err error) {
+	payload := url.Values{}
+	payload.Set("pos", pos)
+	return f.update(ctx, payload)
+}
+
+func (f *CustomField) update(ctx context.Context, payload url.Values) (err error) {
+	body, err := f.client.PutContext(ctx, "/customFields/"+f.ID, payload)
+	if err == nil {
+		err = parseCustomField(body, f, f.client)
+	}
+	return
+}
+
+// Delete - Delete a Custom Field, and its values on every card
+// - https://developer.atlassian.com/cloud/trello/rest/api-group-customfields/#api-customfields-id-delete
+func (f *CustomField) Delete() (err error) {
+	return f.DeleteContext(context.Background())
+}
+
+// DeleteContext - Delete a Custom Field (with context)
+func (f *CustomField) DeleteContext(ctx context.Context) (err error) {
+	_, err = f.client.DeleteContext(ctx, "/customFields/"+f.ID)
+	return
+}
+
+// AddOption - Add an option to a dropdown Custom Field (color may be empty)
+// - https://developer.atlassian.com/cloud/trello/rest/api-group-customfields/#api-customfields-id-options-post
+func (f *CustomField) AddOption(text, color string) (option *CustomFieldOption, err error) {
+	return f.AddOptionContext(context.Background(), text, color)
+}
+
+// AddOptionContext - Add an option to a dropdown Custom Field (with context)
+func (f *CustomField) AddOptionContext(ctx context.Context, text, color string) (option *CustomFieldOption, err error) {
+	option = &CustomFieldOption{}
+	payload := map[string]interface{}{
+		"value": map[string]string{"text": text},
+		"color": optionColor(color),
+		"pos":   "bottom",
+	}
+	body, err := f.client.sendJSON(ctx, http.MethodPost, "/customFields/"+f.ID+"/options", payload)
+	if err == nil {
+		if err = json.Unmarshal(body, option); err == nil {
+			option.client = f.client
+			f.Options = append(f.Options, *option)
+		}
+	}
+	return
+}
+
+// Delete - Remove an option from a dropdown Custom Field, clearing it on the cards using it
+// - https://developer.atlassian.com/cloud/trello/rest/api-group-customfields/#api-customfields-id-options-idcustomfieldoption-delete
+func (o *CustomFieldOption) Delete() (err error) {
+	return o.DeleteContext(context.Background())
+}
+
+// DeleteContext - Remove an option from a dropdown Custom Field (with context)
+func (o *CustomFieldOption) DeleteContext(ctx context.Context) (err error) {
+	_, err = o.client.DeleteContext(ctx, "/customFields/"+o.IDCustomField+"/options/"+o.ID)
+	return
+}
+
+// optionColor - Trello names the absence of color "none"
+func optionColor(color string) string {
+	if color == "" {
+		return "none"
+	}
+	return color
+}
+
+// CustomFieldItems - Get the Custom Field values of a Card
+// Without opts the values loaded with IncludeCustomFieldItems are returned, if any.
+// - https://developer.atlassian.com/cloud/trello/rest/api-group-cards/#api-cards-id-customfielditems-get
+func (c *Card) CustomFieldItems(opts ...QueryOption) (items []CustomFieldItem, err error) {
+	return c.CustomFieldItemsContext(context.Background(), opts...)
+}
+
+// CustomFieldItemsContext - Get the Custom Field values of a Card (with context)
+func (c *Card) CustomFieldItemsContext(ctx context.Context, opts ...QueryOption) (items []CustomFieldItem, err error) {
+	if c.customFieldItems != nil && len(opts) == 0 {
+		return c.customFieldItems, nil
+	}
+	body, err := c.client.GetContext(ctx, encodeQuery("/cards/"+c.ID+"/customFieldItems", opts))
+	if err == nil {
+		err = json.Unmarshal(body, &items)
+	}
+	return
+}
+
+// SetCustomFieldText - Set the value of a text Custom Field on a Card
+// - https://developer.atlassian.com/cloud/trello/rest/api-group-customfielditems/#api-cards-idcard-customfield-idcustomfield-item-put
+func (c *Card) SetCustomFieldText(field *CustomField, text string) (item *CustomFieldItem, err error) {
+	return c.SetCustomFieldTextContext(context.Background(), field, text)
+}
+
+// SetCustomFieldTextContext - Set the value of a text Custom Field on a Card (with context)
+func (c *Card) SetCustomFieldTextContext(ctx context.Context, field *CustomField, text string) (item *CustomFieldItem, err error) {
+	return c.setCustomField(ctx, field.ID, map[string]interface{}{"value": CustomFieldValue{Text: &text}})
+}
+
+// SetCustomFieldNumber - Set the value of a number Custom Field on a Card
+// - https://developer.atlassian.com/cloud/trello/rest/api-group-customfielditems/#api-cards-idcard-customfield-idcustomfield-item-put
+func (c *Card) SetCustomFieldNumber(field *CustomField, number float64) (item *CustomFieldItem, err error) {
+	return c.SetCustomFieldNumberContext(context.Background(), field, number)
+}
+
+// SetCustomFieldNumberContext - Set the value of a number Custom Field on a Card (with context)
+func (c *Card) SetCustomFieldNumberContext(ctx context.Context, field *CustomField, number float64) (item *CustomFieldItem, err error) {
+	return c.setCustomField(ctx, field.ID, map[string]interface{}{"value": CustomFieldValue{Number: &number}})
+}
+
+// SetCustomFieldDate - Set the value of a date Custom Field on a Card
+// - https://developer.atlassian.com/cloud/trello/rest/api-group-customfielditems/#api-cards-idcard-customfield-idcustomfield-item-put
+func (c *Card) SetCustomFieldDate(field *CustomField, date time.Time) (item *CustomFieldItem, err error) {
+	return c.SetCustomFieldDateContext(context.Background(), field, date)
+}
+
+// SetCustomFieldDateContext - Set the value of a date Custom Field on a Card (with context)
+func (c *Card) SetCustomFieldDateContext(ctx context.Context, field *CustomField, date time.Time) (item *CustomFieldItem, err error) {
+	return c.setCustomField(ctx, field.ID, map[string]interface{}{"value": CustomFieldValue{Date: &date}})
+}
+
+// SetCustomFieldCheckbox - Check (or uncheck) a checkbox Custom Field on a Card
+// - https://developer.atlassian.com/cloud/trello/rest/api-group-customfielditems/#api-cards-idcard-customfield-idcustomfield-item-put
+func (c *Card) SetCustomFieldCheckbox(field *CustomField, checked bool) (item *CustomFieldItem, err error) {
+	return c.SetCustomFieldCheckboxContext(context.Background(), field, checked)
+}
+
+// SetCustomFieldCheckboxContext - Check (or uncheck) a checkbox Custom Field on a Card (with context)
+func (c *Card) SetCustomFieldCheckboxContext(ctx context.Context, field *CustomField, checked bool) (item *CustomFieldItem, err error) {
+	return c.setCustomField(ctx, field.ID, map[string]interface{}{"value": CustomFieldValue{Checked: &checked}})
+}
+
+// SetCustomFieldOption - Select an option of a dropdown Custom Field on a Card
+// - https://developer.atlassian.com/cloud/trello/rest/api-group-customfielditems/#api-cards-idcard-customfield-idcustomfield-item-put
+func (c *Card) SetCustomFieldOption(option *CustomFieldOption) (item *CustomFieldItem, err error) {
+	return c.SetCustomFieldOptionContext(context.Background(), option)
+}
+
+// SetCustomFieldOptionContext - Select an option of a dropdown Custom Field on a Card (with context)
+func (c *Card) SetCustomFieldOptionContext(ctx context.Context, option *CustomFieldOption) (item *CustomFieldItem, err error) {
+	return c.setCustomField(ctx, option.IDCustomField, map[string]interface{}{"idValue": option.ID})
+}
+
+// ClearCustomField - Remove the value of a Custom Field from a Card
+// - https://developer.atlassian.com/cloud/trello/rest/api-group-customfielditems/#api-cards-idcard-customfield-idcustomfield-item-put
+func (c *Card) ClearCustomField(field *CustomField) (err error) {
+	return c.ClearCustomFieldContext(context.Background(), field)
+}
+
+// ClearCustomFieldContext - Remove the value of a Custom Field from a Card (with context)
+func (c *Card) ClearCustomFieldContext(ctx context.Context, field *CustomField) (err error) {
+	_, err = c.setCustomField(ctx, field.ID, map[string]interface{}{"value": "", "idValue": ""})
+	return
+}
+
+func (c *Card) setCustomField(ctx context.Context, fieldID string, payload map[string]interface{}) (item *CustomFieldItem, err error) {
+	body, err := c.client.sendJSON(ctx, http.MethodPut, "/cards/"+c.ID+"/customField/"+fieldID+"/item", payload)
+	if err == nil {
+		c.customFieldItems = nil
+		item = &CustomFieldItem{}
+		err = json.Unmarshal(body, item)
+	}
+	return
+}
+
+func parseCustomField(body []byte, field *CustomField, client *Client) (err error) {
+	err = json.Unmarshal(body, &field)
+	if err == nil {
+		field.client = client
+		for i := range field.Options {
+			field.Options[i].client = client
+		}
+	}
+	return
+}
+
+func parseListCustomFields(body []byte, client *Client) (fields []CustomField, err error) {
+	err = json.Unmarshal(body, &fields)
+	for i := range fields {
+		fields[i].client = client
+		for j := range fields[i].Options {
+			fields[i].Options[j].client = client
+		}
+	}
+	return
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trello
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	goblin "github.com/franela/goblin"
+	. "github.com/onsi/gomega"
+)
+
+func TestCustomFields(t *testing.T) {
+	g := goblin.Goblin(t)
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+
+	g.Describe("Custom field tests", func() {
+		var board *Board
+		var card *Card
+		var points, severity, due, done, customer *CustomField
+
+		g.Before(func() {
+			board, err = client.CreateBoard(testName("GoTestTrello-CustomFields"))
+			Expect(err).To(BeNil())
+			lists, err := board.Lists()
+			Expect(err).To(BeNil())
+			card, err = lists[0].AddCard(Card{Name: "Story"})
+			Expect(err).To(BeNil())
+		})
+
+		g.It("should create custom fields", func() {
+			points, err = board.AddCustomField(CustomField{Name: "Story points", Type: CustomFieldNumber})
+			Expect(err).To(BeNil())
+			Expect(points.Type).To(Equal(CustomFieldNumber))
+			Expect(points.IDModel).To(Equal(board.ID))
+
+			opts := CustomField{Name: "Severity", Type: CustomFieldList}
+			opts.Display.CardFront = true
+			opts.Options = make([]CustomFieldOption, 2)
+			opts.Options[0].Value.Text = "Low"
+			opts.Options[1].Value.Text = "High"
+			opts.Options[1].Color = "red"
+			severity, err = board.AddCustomField(opts)
+			Expect(err).To(BeNil())
+			Expect(severity.Display.CardFront).To(BeTrue())
+			Expect(len(severity.Options)).To(Equal(2))
+			Expect(severity.Options[1].Value.Text).To(Equal("High"))
+			Expect(severity.Options[1].Color).To(Equal("red"))
+
+			due, err = board.AddCustomField(CustomField{Name: "Deadline", Type: CustomFieldDate})
+			Expect(err).To(BeNil())
+			done, err = board.AddCustomField(CustomField{Name: "Reviewed", Type: CustomFieldCheckbox})
+			Expect(err).To(BeNil())
+			customer, err = board.AddCustomField(CustomField{Name: "Customer", Type: CustomFieldText})
+			Expect(err).To(BeNil())
+
+			_, err = board.AddCustomField(CustomField{Name: "Broken", Type: "color"})
+			Expect(err).NotTo(BeNil())
+		})
+
+		g.It("should list and update custom field definitions", func() {
+			fields, err := board.CustomFields()
+			Expect(err).To(BeNil())
+			Expect(len(fields)).To(Equal(5))
+			Expect(fields[0].ID).To(Equal(points.ID))
+
+			Expect(customer.SetName("Client")).To(BeNil())
+			Expect(customer.Name).To(Equal("Client"))
+			Expect(customer.SetCardFront(true)).To(BeNil())
+			Expect(customer.Display.CardFront).To(BeTrue())
+			Expect(customer.Move("top")).To(BeNil())
+
+			fields, err = board.CustomFields()
+			Expect(err).To(BeNil())
+			Expect(fields[0].ID).To(Equal(customer.ID))
+
+			fetched, err := client.CustomField(customer.ID)
+			Expect(err).To(BeNil())
+			Expect(fetched.Name).To(Equal("Client"))
+		})
+
+		g.It("should manage the options of a dropdown field", func() {
+			option, err := severity.AddOption("Critical", "purple")
+			Expect(err).To(BeNil())
+			Expect(option.IDCustomField).To(Equal(severity.ID))
+			Expect(len(severity.Options)).To(Equal(3))
+			Expect(option.Delete()).To(BeNil())
+
+			fetched, err := client.CustomField(severity.ID)
+			Expect(err).To(BeNil())
+			Expect(len(fetched.Options)).To(Equal(2))
+		})
+
+		g.It("should set typed values on a card", func() {
+			item, err := card.SetCustomFieldNumber(points, 5)
+			Expect(err).To(BeNil())
+			Expect(*item.Value.Number).To(Equal(5.0))
+			_, err = card.SetCustomFieldText(customer, "ACME")
+			Expect(err).To(BeNil())
+			deadline := time.Date(2030, 6, 1, 12, 0, 0, 0, time.UTC)
+			_, err = card.SetCustomFieldDate(due, deadline)
+			Expect(err).To(BeNil())
+			_, err = card.SetCustomFieldCheckbox(done, true)
+			Expect(err).To(BeNil())
+			item, err = card.SetCustomFieldOption(&severity.Options[1])
+			Expect(err).To(BeNil())
+			Expect(item.IDValue).To(Equal(severity.Options[1].ID))
+			Expect(item.Value).To(BeNil())
+
+			_, err = card.SetCustomFieldText(points, "five")
+			Expect(err).NotTo(BeNil())
+
+			fetched, err := client.Card(card.ID, IncludeCustomFieldItems())
+			Expect(err).To(BeNil())
+			items, err := fetched.CustomFieldItems()
+			Expect(err).To(BeNil())
+			Expect(len(items)).To(Equal(5))
+			values := map[string]CustomFieldItem{}
+			for _, item := range items {
+				values[item.IDCustomField] = item
+			}
+			Expect(*values[points.ID].Value.Number).To(Equal(5.0))
+			Expect(*values[customer.ID].Value.Text).To(Equal("ACME"))
+			Expect(values[due.ID].Value.Date.Equal(deadline)).To(BeTrue())
+			Expect(*values[done.ID].Value.Checked).To(BeTrue())
+			Expect(values[severity.ID].IDValue).To(Equal(severity.Options[1].ID))
+		})
+
+		g.It("should clear values and drop them with their field", func() {
+			Expect(card.ClearCustomField(points)).To(BeNil())
+			Expect(severity.Delete()).To(BeNil())
+			items, err := card.CustomFieldItems()
+			Expect(err).To(BeNil())
+			Expect(len(items)).To(Equal(3))
+		})
+
+		g.It("should encode values the way Trello does", func() {
+			n, checked := 2.5, false
+			data, err := json.Marshal(CustomFieldValue{Number: &n})
+			Expect(err).To(BeNil())
+			Expect(string(data)).To(Equal(`{"number":"2.5"}`))
+			data, err = json.Marshal(CustomFieldValue{Checked: &checked})
+			Expect(err).To(BeNil())
+			Expect(string(data)).To(Equal(`{"checked":"false"}`))
+
+			var value CustomFieldValue
+			Expect(json.Unmarshal([]byte(`{"number":"many"}`), &value)).NotTo(BeNil())
+		})
+
+		g.After(func() {
+			err = board.Delete()
+			Expect(err).To(BeNil())
+		})
+	})
+}
@@ -0,0 +1,275 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trello
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// ActionHandler - Function handling an action
+type ActionHandler func(ctx context.Context, action *Action)
+
+// Middleware - Wraps the handling of every action dispatched (logging, recovery...)
+type Middleware func(next ActionHandler) ActionHandler
+
+// Dispatcher - Routes actions to the handlers registered for their ActionType
+// Actions can come from a WebhookHandler (see WebhookCallback) or be dispatched directly.
+type Dispatcher struct {
+	mu         sync.RWMutex
+	handlers   map[ActionType][]ActionHandler
+	any        []ActionHandler
+	middleware []Middleware
+}
+
+// NewDispatcher - Create a Dispatcher without handlers
+func NewDispatcher() *Dispatcher {
+	return &Dispatcher{
+		handlers: map[ActionType][]ActionHandler{},
+	}
+}
+
+// Use - Add middleware; the first added is the outermost
+func (d *Dispatcher) Use(middleware ...Middleware) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.middleware = append(d.middleware, middleware...)
+}
+
+// On - Register a handler for actions of type actionType
+func (d *Dispatcher) On(actionType ActionType, handler ActionHandler) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.handlers[actionType] = append(d.handlers[actionType], handler)
+}
+
+// OnAny - Register a handler for actions of every type
+func (d *Dispatcher) OnAny(handler ActionHandler) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.any = append(d.any, handler)
+}
+
+// Dispatch - Run the middleware and the handlers registered for the action type
+func (d *Dispatcher) Dispatch(ctx context.Context, action *Action) {
+	d.mu.RLock()
+	handlers := append(append([]ActionHandler(nil), d.handlers[action.Type]...), d.any...)
+	middleware := append([]Middleware(nil), d.middleware...)
+	d.mu.RUnlock()
+
+	next := func(ctx context.Context, action *Action) {
+		for _, handler := range handlers {
+			runHandler(ctx, action, handler)
+		}
+	}
+	for i := len(middleware) - 1; i >= 0; i-- {
+		next = middleware[i](next)
+	}
+	next(ctx, action)
+}
+
+// runHandler - Run a single handler, recovering from its panic if RecoveryMiddleware is in use
+// so the handlers after it still run
+func runHandler(ctx context.Context, action *Action, handler ActionHandler) {
+	if report, ok := ctx.Value(recoveryKey{}).(func(*Action, interface{})); ok {
+		defer func() {
+			if r := recover(); r != nil {
+				report(action, r)
+			}
+		}()
+	}
+	handler(ctx, action)
+}
+
+// WebhookCallback - Callback dispatching webhook actions, for WebhookHandler.Handle
+func (d *Dispatcher) WebhookCallback() WebhookCallback {
+	return func(ctx context.Context, event *WebhookEvent) error {
+		d.Dispatch(ctx, &event.Action)
+		return nil
+	}
+}
+
+// OnCreateCard - Register a handler for createCard actions
+func (d *Dispatcher) OnCreateCard(handler ActionHandler) {
+	d.On(CreateCard, handler)
+}
+
+// OnUpdateCard - Register a handler for updateCard actions (renames, moves, archiving...)
+func (d *Dispatcher) OnUpdateCard(handler ActionHandler) {
+	d.On(UpdateCard, handler)
+}
+
+// OnDeleteCard - Register a handler for deleteCard actions
+func (d *Dispatcher) OnDeleteCard(handler ActionHandler) {
+	d.On(DeleteCard, handler)
+}
+
+// OnCopyCard - Register a handler for copyCard actions
+func (d *Dispatcher) OnCopyCard(handler ActionHandler) {
+	d.On(CopyCard, handler)
+}
+
+// OnCommentCard - Register a handler for commentCard actions
+func (d *Dispatcher) OnCommentCard(handler ActionHandler) {
+	d.On(CommentCard, handler)
+}
+
+// OnAddMemberToCard - Register a handler for addMemberToCard actions
+func (d *Dispatcher) OnAddMemberToCard(handler ActionHandler) {
+	d.On(AddMemberToCard, handler)
+}
+
+// OnRemoveMemberFromCard - Register a handler for removeMemberFromCard actions
+func (d *Dispatcher) OnRemoveMemberFromCard(handler ActionHandler) {
+	d.On(RemoveMemberFromCard, handler)
+}
+
+// OnAddLabelToCard - Register a handler for addLabelToCard actions
+func (d *Dispatcher) OnAddLabelToCard(handler ActionHandler) {
+	d.On(AddLabelToCard, handler)
+}
+
+// OnRemoveLabelFromCard - Register a handler for removeLabelFromCard actions
+func (d *Dispatcher) OnRemoveLabelFromCard(handler ActionHandler) {
+	d.On(RemoveLabelFromCard, handler)
+}
+
+// OnAddAttachmentToCard - Register a handler for addAttachmentToCard actions
+func (d *Dispatcher) OnAddAttachmentToCard(handler ActionHandler) {
+	d.On(AddAttachmentToCard, handler)
+}
+
+// OnDeleteAttachmentFromCard - Register a handler for deleteAttachmentFromCard actions
+func (d *Dispatcher) OnDeleteAttachmentFromCard(handler ActionHandler) {
+	d.On(DeleteAttachmentFromCard, handler)
+}
+
+// OnAddChecklistToCard - Register a handler for addChecklistToCard actions
+func (d *Dispatcher) OnAddChecklistToCard(handler ActionHandler) {
+	d.On(AddChecklistToCard, handler)
+}
+
+// OnRemoveChecklistFromCard - Register a handler for removeChecklistFromCard actions
+func (d *Dispatcher) OnRemoveChecklistFromCard(handler ActionHandler) {
+	d.On(RemoveChecklistFromCard, handler)
+}
+
+// OnUpdateCheckItemStateOnCard - Register a handler for updateCheckItemStateOnCard actions
+func (d *Dispatcher) OnUpdateCheckItemStateOnCard(handler ActionHandler) {
+	d.On(UpdateCheckItemStateOnCard, handler)
+}
+
+// OnMoveCardToBoard - Register a handler for moveCardToBoard actions
+func (d *Dispatcher) OnMoveCardToBoard(handler ActionHandler) {
+	d.On(MoveCardToBoard, handler)
+}
+
+// OnMoveCardFromBoard - Register a handler for moveCardFromBoard actions
+func (d *Dispatcher) OnMoveCardFromBoard(handler ActionHandler) {
+	d.On(MoveCardFromBoard, handler)
+}
+
+// OnCreateList - Register a handler for createList actions
+func (d *Dispatcher) OnCreateList(handler ActionHandler) {
+	d.On(CreateList, handler)
+}
+
+// OnUpdateList - Register a handler for updateList actions
+func (d *Dispatcher) OnUpdateList(handler ActionHandler) {
+	d.On(UpdateList, handler)
+}
+
+// OnUpdateBoard - Register a handler for updateBoard actions
+func (d *Dispatcher) OnUpdateBoard(handler ActionHandler) {
+	d.On(UpdateBoard, handler)
+}
+
+// OnAddMemberToBoard - Register a handler for addMemberToBoard actions
+func (d *Dispatcher) OnAddMemberToBoard(handler ActionHandler) {
+	d.On(AddMemberToBoard, handler)
+}
+
+// OnRemoveMemberFromBoard - Register a handler for removeMemberFromBoard actions
+func (d *Dispatcher) OnRemoveMemberFromBoard(handler ActionHandler) {
+	d.On(RemoveMemberFromBoard, handler)
+}
+
+// LoggingMiddleware - Log every action dispatched and how long its handlers took (logger may be nil)
+func LoggingMiddleware(logger Logger) Middleware {
+	return func(next ActionHandler) ActionHandler {
+		if logger == nil {
+			return next
+		}
+		return func(ctx context.Context, action *Action) {
+			start := time.Now()
+			next(ctx, action)
+			logger.Printf("trello: handled %s action %s (%v)", action.Type, action.ID, time.Since(start))
+		}
+	}
+}
+
+// recoveryKey - Context key of the panic reporter set by RecoveryMiddleware
+type recoveryKey struct{}
+
+// RecoveryMiddleware - Recover from panics in handlers, logging them (logger may be nil)
+// Each handler is recovered on its own, so the handlers after the one that panicked still
+// run; panics in the middleware added after this one are recovered too.
+func RecoveryMiddleware(logger Logger) Middleware {
+	report := func(action *Action, r interface{}) {
+		if logger != nil {
+			logger.Printf("trello: panic handling %s action %s: %v", action.Type, action.ID, r)
+		}
+	}
+	return func(next ActionHandler) ActionHandler {
+		return func(ctx context.Context, action *Action) {
+			defer func() {
+				if r := recover(); r != nil {
+					report(action, r)
+				}
+			}()
+			next(context.WithValue(ctx, recoveryKey{}, report), action)
+		}
+	}
+}
+
+// DedupMiddleware - Skip actions whose ID was among the last size actions dispatched
+func DedupMiddleware(size int) Middleware {
+	if size < 1 {
+		size = 1
+	}
+	var mu sync.Mutex
+	seen := make(map[string]bool, size)
+	recent := make([]string, 0, size)
+	return func(next ActionHandler) ActionHandler {
+		return func(ctx context.Context, action *Action) {
+			mu.Lock()
+			if seen[action.ID] {
+				mu.Unlock()
+				return
+			}
+			if len(recent) == size {
+				delete(seen, recent[0])
+				recent = recent[1:]
+			}
+			seen[action.ID] = true
+			recent = append(recent, action.ID)
+			mu.Unlock()
+			next(ctx, action)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trello
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	goblin "github.com/franela/goblin"
+	. "github.com/onsi/gomega"
+)
+
+func TestDispatcher(t *testing.T) {
+	g := goblin.Goblin(t)
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+
+	g.Describe("Dispatcher tests", func() {
+		var dispatcher *Dispatcher
+		var handled []string
+		ctx := context.Background()
+
+		record := func(name string) ActionHandler {
+			return func(ctx context.Context, action *Action) {
+				handled = append(handled, name+":"+action.ID)
+			}
+		}
+
+		g.BeforeEach(func() {
+			dispatcher = NewDispatcher()
+			handled = nil
+		})
+
+		g.It("should route actions by type", func() {
+			dispatcher.OnCreateCard(record("create"))
+			dispatcher.OnCommentCard(record("comment"))
+			dispatcher.On(UpdateCard, record("update"))
+			dispatcher.OnUpdateCard(record("update2"))
+
+			dispatcher.Dispatch(ctx, &Action{ID: "1", Type: CreateCard})
+			dispatcher.Dispatch(ctx, &Action{ID: "2", Type: UpdateCard})
+			dispatcher.Dispatch(ctx, &Action{ID: "3", Type: DeleteCard})
+			Expect(handled).To(Equal([]string{"create:1", "update:2", "update2:2"}))
+		})
+
+		g.It("should call catch-all handlers after typed ones", func() {
+			dispatcher.OnAny(record("any"))
+			dispatcher.OnAddMemberToCard(record("member"))
+
+			dispatcher.Dispatch(ctx, &Action{ID: "1", Type: AddMemberToCard})
+			dispatcher.Dispatch(ctx, &Action{ID: "2", Type: CreateList})
+			Expect(handled).To(Equal([]string{"member:1", "any:1", "any:2"}))
+		})
+
+		g.It("should run middleware in the order added", func() {
+			trace := func(name string) Middleware {
+				return func(next ActionHandler) ActionHandler {
+					return func(ctx context.Context, action *Action) {
+						handled = append(handled, name+">")
+						next(ctx, action)
+						handled = append(handled, "<"+name)
+					}
+				}
+			}
+			dispatcher.Use(trace("outer"), trace("inner"))
+			dispatcher.OnCreateCard(record("create"))
+
+			dispatcher.Dispatch(ctx, &Action{ID: "1", Type: CreateCard})
+			Expect(handled).To(Equal([]string{"outer>", "inner>", "create:1", "<inner", "<outer"}))
+		})
+
+		g.It("should log dispatched actions", func() {
+			var logged bytes.Buffer
+			dispatcher.Use(LoggingMiddleware(log.New(&logged, "", 0)))
+			dispatcher.Dispatch(ctx, &Action{ID: "5f0000000000000000000001", Type: CreateCard})
+			Expect(logged.String()).To(ContainSubstring("handled createCard action 5f0000000000000000000001"))
+
+			dispatcher = NewDispatcher()
+			dispatcher.Use(LoggingMiddleware(nil))
+			handled := 0
+			dispatcher.OnCreateCard(func(ctx context.Context, action *Action) { handled++ })
+			Expect(func() { dispatcher.Dispatch(ctx, &Action{ID: "1", Type: CreateCard}) }).NotTo(Panic())
+			Expect(handled).To(Equal(1))
+		})
+
+		g.It("should recover from panics in handlers", func() {
+			var logged bytes.Buffer
+			dispatcher.Use(RecoveryMiddleware(log.New(&logged, "", 0)))
+			dispatcher.OnCreateCard(func(ctx context.Context, action *Action) {
+				panic("boom")
+			})
+			dispatcher.OnCreateCard(record("after"))
+			dispatcher.OnAny(func(ctx context.Context, action *Action) {
+				panic("bang")
+			})
+			dispatcher.OnAny(record("any"))
+
+			Expect(func() { dispatcher.Dispatch(ctx, &Action{ID: "1", Type: CreateCard}) }).NotTo(Panic())
+			Expect(logged.String()).To(ContainSubstring("panic handling createCard action 1: boom"))
+			Expect(logged.String()).To(ContainSubstring("panic handling createCard action 1: bang"))
+			// The handlers after the ones that panicked still ran
+			Expect(handled).To(Equal([]string{"after:1", "any:1"}))
+			dispatcher = NewDispatcher()
+			dispatcher.Use(RecoveryMiddleware(nil))
+			dispatcher.OnCreateCard(func(ctx context.Context, action *Action) {
+				panic("boom")
+			})
+			Expect(func() { dispatcher.Dispatch(ctx, &Action{ID: "1", Type: CreateCard}) }).NotTo(Panic())
+		})
+
+		g.It("should skip duplicate actions", func() {
+			dispatcher.Use(DedupMiddleware(2))
+			dispatcher.OnAny(record("any"))
+
+			for _, id := range []string{"1", "2", "1", "3", "1"} {
+				dispatcher.Dispatch(ctx, &Action{ID: id, Type: CreateCard})
+			}
+			// "1" is forgotten once two newer actions were seen
+			Expect(handled).To(Equal([]string{"any:1", "any:2", "any:3", "any:1"}))
+		})
+
+		g.It("should dispatch webhook events", func() {
+			dispatcher.OnUpdateCard(record("update"))
+			webhook := NewWebhookHandler(testWebhookSecret, testWebhookCallback)
+			webhook.Handle(dispatcher.WebhookCallback())
+
+			body := webhookPayload("5f00000000000000000000b1", time.Now())
+			rec := httptest.NewRecorder()
+			req := webhookRequest(http.MethodPost, body, SignWebhook(testWebhookSecret, testWebhookCallback, []byte(body)))
+			webhook.ServeHTTP(rec, req)
+			Expect(rec.Code).To(Equal(http.StatusOK))
+			Expect(handled).To(Equal([]string{"update:5f00000000000000000000b1"}))
+		})
+	})
+}
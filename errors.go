@@ -0,0 +1,89 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trello
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Sentinel errors for use with errors.Is on an *APIError
+var (
+	ErrNotFound     = errors.New("trello: resource not found")
+	ErrUnauthorized = errors.New("trello: unauthorized")
+	ErrRateLimited  = errors.New("trello: rate limited")
+)
+
+// requestIDHeaders - response headers that may carry the Trello request ID
+var requestIDHeaders = []string{"X-Trello-Request-Id", "X-Request-Id"}
+
+// APIError - Error returned when the Trello API responds with an unexpected status
+// Resource is relative to the client endpoint and never includes the key or token.
+type APIError struct {
+	StatusCode int
+	Method     string
+	Resource   string
+	Body       string
+	RequestID  string
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	return fmt.Sprintf("Received unexpected status %d for %s %s: %q", e.StatusCode, e.Method, e.Resource, e.Body)
+}
+
+// Is allows errors.Is to match an *APIError against the sentinel errors
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	}
+	return false
+}
+
+// newAPIError - Build an *APIError from a response, scrubbing credentials from the resource
+func newAPIError(resource string, req *http.Request, resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Method:     req.Method,
+		Resource:   resource,
+		Body:       string(body),
+	}
+	for _, h := range requestIDHeaders {
+		if id := resp.Header.Get(h); id != "" {
+			apiErr.RequestID = id
+			break
+		}
+	}
+	return apiErr
+}
+
+// scrubURL - Return the URL as a string with the key and token query parameters removed
+func scrubURL(u *url.URL) string {
+	scrubbed := *u
+	values := scrubbed.Query()
+	values.Del("key")
+	values.Del("token")
+	scrubbed.RawQuery = values.Encode()
+	return scrubbed.String()
+}
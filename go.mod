@@ -1,8 +1,15 @@
 module github.com/TJM/go-trello
 
-go 1.15
+go 1.23
 
 require (
 	github.com/franela/goblin v0.0.0-20201006155558-6240afcb2eb7
 	github.com/onsi/gomega v1.10.3
+	gopkg.in/yaml.v2 v2.3.0
+)
+
+require (
+	golang.org/x/net v0.0.0-20201006153459-a7d1128ccaa0 // indirect
+	golang.org/x/text v0.3.3 // indirect
+	golang.org/x/xerrors v0.0.0-20191204190536-9bdfabe68543 // indirect
 )
@@ -0,0 +1,43 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trello
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// IDTime - Creation time encoded in a Trello object ID
+// IDs are 24 hex digits, the first 8 being the creation time in seconds since the Unix epoch.
+func IDTime(id string) (t time.Time, err error) {
+	if _, err = hex.DecodeString(id); err != nil || len(id) != 24 {
+		return t, fmt.Errorf("Invalid Trello ID %q: expected 24 hex digits", id)
+	}
+	seconds, err := strconv.ParseUint(id[:8], 16, 32)
+	if err == nil {
+		t = time.Unix(int64(seconds), 0).UTC()
+	}
+	return
+}
+
+// CreatedAt - When the Card was created, from its ID (zero if the ID is not set)
+func (c *Card) CreatedAt() time.Time {
+	t, _ := IDTime(c.ID)
+	return t
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trello
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	goblin "github.com/franela/goblin"
+	. "github.com/onsi/gomega"
+)
+
+func TestTimes(t *testing.T) {
+	g := goblin.Goblin(t)
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+
+	g.Describe("Time tests", func() {
+		var board *Board
+		var list List
+
+		g.Before(func() {
+			board, err = client.CreateBoard(testName("GoTestTrello-Times"))
+			Expect(err).To(BeNil())
+			lists, err := board.Lists()
+			Expect(err).To(BeNil())
+			list = lists[0]
+		})
+
+		g.It("should extract the creation time from an ID", func() {
+			created, err := IDTime("4d5ea62fd76aa1136000000c")
+			Expect(err).To(BeNil())
+			Expect(created).To(Equal(time.Date(2011, 2, 18, 17, 2, 39, 0, time.UTC)))
+
+			for _, id := range []string{"", "4d5ea62f", "4d5ea62fd76aa1136000000z", "4d5ea62fd76aa1136000000c00"} {
+				_, err = IDTime(id)
+				Expect(err).NotTo(BeNil())
+			}
+			Expect((&Card{}).CreatedAt().IsZero()).To(BeTrue())
+		})
+
+		g.It("should parse card dates", func() {
+			due := time.Date(2030, 1, 2, 15, 4, 5, 0, time.UTC)
+			card, err := list.AddCard(Card{Name: "Due", Due: &due})
+			Expect(err).To(BeNil())
+			Expect(card.Due).NotTo(BeNil())
+			Expect(card.Due.Equal(due)).To(BeTrue())
+			Expect(time.Since(card.DateLastActivity)).To(BeNumerically("<", time.Hour))
+			Expect(time.Since(card.CreatedAt())).To(BeNumerically("<", time.Hour))
+
+			undated, err := list.AddCard(Card{Name: "Not Due"})
+			Expect(err).To(BeNil())
+			Expect(undated.Due).To(BeNil())
+		})
+
+		g.It("should parse action dates", func() {
+			actions, err := board.Actions()
+			Expect(err).To(BeNil())
+			Expect(actions).NotTo(BeEmpty())
+			for _, action := range actions {
+				created, err := IDTime(action.ID)
+				Expect(err).To(BeNil())
+				Expect(action.Date.Sub(created)).To(BeNumerically("~", 0, time.Second))
+			}
+		})
+
+		g.It("should round trip dates through JSON", func() {
+			due := time.Date(2030, 1, 2, 15, 4, 5, 123000000, time.UTC)
+			body, err := json.Marshal(Card{Due: &due, DateLastActivity: due})
+			Expect(err).To(BeNil())
+			Expect(string(body)).To(ContainSubstring(`"due":"2030-01-02T15:04:05.123Z"`))
+			card := Card{}
+			Expect(json.Unmarshal(body, &card)).To(BeNil())
+			Expect(card.Due.Equal(due)).To(BeTrue())
+			Expect(card.DateLastActivity.Equal(due)).To(BeTrue())
+
+			body, err = json.Marshal(Card{})
+			Expect(err).To(BeNil())
+			Expect(string(body)).To(ContainSubstring(`"due":null`))
+			Expect(json.Unmarshal(body, &card)).To(BeNil())
+			Expect(card.Due).To(BeNil())
+		})
+
+		g.After(func() {
+			err = board.Delete()
+			Expect(err).To(BeNil())
+		})
+	})
+}
@@ -1,26 +1,80 @@
 package trello
 
 import (
+	"fmt"
 	"log"
 	"math/rand"
+	"net/http"
 	"os"
+	"testing"
 	"time"
+
+	"github.com/TJM/go-trello/trellotest"
 )
 
 // client is used in all tests
 var client *Client
 var err error
 
+// apiToken is the token the client authenticates with
+var apiToken string
+
+// recorder is set when the tests record or replay a cassette
+var recorder *trellotest.Recorder
+
 // Test initialization
+// When API_KEY and API_TOKEN are set the tests run against Trello, otherwise
+// they run against the in-memory fake in the trellotest package.
+// When TRELLO_CASSETTE is set the exchanges are replayed from that file, or
+// recorded to it (against Trello or the fake) if TRELLO_RECORD is also set.
 func init() {
 	key := os.Getenv("API_KEY")
 	token := os.Getenv("API_TOKEN")
+	cassette := os.Getenv("TRELLO_CASSETTE")
+	rand.Seed(time.Now().UnixNano())
+
+	opts := []Option{}
 	if key == "" || token == "" {
-		log.Fatal("ERROR: Environment vars API_KEY and API_TOKEN must be set for trello tests.")
+		key, token = "test-key", "test-token"
+		if cassette == "" || os.Getenv("TRELLO_RECORD") != "" {
+			server := trellotest.NewServer()
+			server.Key, server.Token = key, token
+			opts = append(opts, WithBaseURL(server.Endpoint()))
+		}
 	}
-	rand.Seed(time.Now().UnixNano())
-	client, err = NewAuthClient(key, &token)
+	if cassette != "" {
+		mode := trellotest.ReplayMode
+		if os.Getenv("TRELLO_RECORD") != "" {
+			mode = trellotest.RecordMode
+		}
+		recorder, err = trellotest.NewRecorder(cassette, mode, nil)
+		if err != nil {
+			log.Fatal("Error loading cassette: " + err.Error())
+		}
+		opts = append(opts, WithHTTPClient(&http.Client{Transport: recorder}))
+	}
+	client, err = NewClient(append(opts, WithAuth(key, &token))...)
 	if err != nil {
 		log.Fatal("Error setting up client.")
 	}
+	apiToken = token
+}
+
+func TestMain(m *testing.M) {
+	code := m.Run()
+	if recorder != nil {
+		if err := recorder.Save(); err != nil {
+			log.Fatal("Error saving cassette: " + err.Error())
+		}
+	}
+	os.Exit(code)
+}
+
+// testName returns a unique name for test resources
+// With a cassette the name must not change between recording and replaying.
+func testName(prefix string) string {
+	if recorder != nil {
+		return prefix + "-cassette"
+	}
+	return fmt.Sprintf("%s-%v", prefix, time.Now().Unix())
 }
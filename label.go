@@ -1,6 +1,7 @@
 package trello
 
 import (
+	"context"
 	"encoding/json"
 	"net/url"
 )
@@ -19,23 +20,38 @@ type Label struct {
 // SetName - Set Name on a Label (Update a Label)
 // - https://developer.atlassian.com/cloud/trello/rest/api-group-labels/#api-labels-id-put
 func (l *Label) SetName(name string) (err error) {
-	return l.Update("name", name)
+	return l.SetNameContext(context.Background(), name)
+}
+
+// SetNameContext - Set Name on a Label (with context)
+func (l *Label) SetNameContext(ctx context.Context, name string) (err error) {
+	return l.UpdateContext(ctx, "name", name)
 }
 
 // SetColor - Set Color for Label (Update a Label)
 // - https://developer.atlassian.com/cloud/trello/rest/api-group-labels/#api-labels-id-put
 // Color can be null
 func (l *Label) SetColor(color string) (err error) {
-	return l.Update("color", color)
+	return l.SetColorContext(context.Background(), color)
+}
+
+// SetColorContext - Set Color for Label (with context)
+func (l *Label) SetColorContext(ctx context.Context, color string) (err error) {
+	return l.UpdateContext(ctx, "color", color)
 }
 
 // Update - Update a Label (path and value, see API docs for details)
 // - https://developer.atlassian.com/cloud/trello/rest/api-group-boards/#api-boards-id-put
 func (l *Label) Update(path, value string) (err error) {
+	return l.UpdateContext(context.Background(), path, value)
+}
+
+// UpdateContext - Update a Label (with context)
+func (l *Label) UpdateContext(ctx context.Context, path, value string) (err error) {
 	payload := url.Values{}
 	payload.Set("value", value)
 
-	body, err := l.client.Put("/labels/"+l.ID+"/"+path, payload)
+	body, err := l.client.PutContext(ctx, "/labels/"+l.ID+"/"+path, payload)
 	if err == nil {
 		err = parseLabel(body, l, l.client)
 	}
@@ -45,7 +61,12 @@ func (l *Label) Update(path, value string) (err error) {
 // Delete - Delete a Label
 // - https://developer.atlassian.com/cloud/trello/rest/api-group-labels/#api-labels-id-delete
 func (l *Label) Delete() error {
-	_, err := l.client.Delete("/labels/" + l.ID)
+	return l.DeleteContext(context.Background())
+}
+
+// DeleteContext - Delete a Label (with context)
+func (l *Label) DeleteContext(ctx context.Context) error {
+	_, err := l.client.DeleteContext(ctx, "/labels/"+l.ID)
 	return err
 }
 
@@ -17,10 +17,15 @@ limitations under the License.
 package trello
 
 import (
+	"context"
 	"encoding/json"
+	"io"
+	"iter"
 	"net/url"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // List - Trello List Type
@@ -31,14 +36,22 @@ type List struct {
 	Closed  bool    `json:"closed"`
 	IDBoard string  `json:"idBoard"`
 	Pos     float32 `json:"pos"`
-	cards   []Card
+
+	// Nested cards, set when the list is fetched with IncludeCards
+	cards []Card
 }
 
 // List - Get List by listID (string)
+// Cards requested with IncludeCards are returned by List.Cards without another request.
 // - https://developer.atlassian.com/cloud/trello/rest/api-group-lists/#api-lists-id-get
-func (c *Client) List(listID string) (list *List, err error) {
+func (c *Client) List(listID string, opts ...QueryOption) (list *List, err error) {
+	return c.ListContext(context.Background(), listID, opts...)
+}
+
+// ListContext - Get List by listID (with context)
+func (c *Client) ListContext(ctx context.Context, listID string, opts ...QueryOption) (list *List, err error) {
 	list = &List{}
-	body, err := c.Get("/lists/" + listID)
+	body, err := c.GetContext(ctx, encodeQuery("/lists/"+listID, opts))
 	if err == nil {
 		err = parseList(body, list, c)
 	}
@@ -46,9 +59,18 @@ func (c *Client) List(listID string) (list *List, err error) {
 }
 
 // Cards - Get Cards in a List
+// Without opts the cards loaded with IncludeCards are returned, if any.
 // - https://developer.atlassian.com/cloud/trello/rest/api-group-lists/#api-lists-id-cards-get
-func (l *List) Cards() (cards []Card, err error) {
-	body, err := l.client.Get("/lists/" + l.ID + "/cards")
+func (l *List) Cards(opts ...QueryOption) (cards []Card, err error) {
+	return l.CardsContext(context.Background(), opts...)
+}
+
+// CardsContext - Get Cards in a List (with context)
+func (l *List) CardsContext(ctx context.Context, opts ...QueryOption) (cards []Card, err error) {
+	if l.cards != nil && len(opts) == 0 {
+		return l.cards, nil
+	}
+	body, err := l.client.GetContext(ctx, encodeQuery("/lists/"+l.ID+"/cards", opts))
 	if err == nil {
 		cards, err = parseListCards(body, l.client)
 	}
@@ -57,34 +79,162 @@ func (l *List) Cards() (cards []Card, err error) {
 
 // Actions - Get Actions for a List
 // - https://developer.atlassian.com/cloud/trello/rest/api-group-lists/#api-lists-id-actions-get
-func (l *List) Actions() (actions []Action, err error) {
-	body, err := l.client.Get("/lists/" + l.ID + "/actions")
+func (l *List) Actions(opts ...QueryOption) (actions []Action, err error) {
+	return l.ActionsContext(context.Background(), opts...)
+}
+
+// ActionsContext - Get Actions for a List (with context)
+func (l *List) ActionsContext(ctx context.Context, opts ...QueryOption) (actions []Action, err error) {
+	body, err := l.client.GetContext(ctx, encodeQuery("/lists/"+l.ID+"/actions", opts))
 	if err == nil {
 		actions, err = parseListActions(body, l.client)
 	}
 	return
 }
 
+// ActionsIter - Iterate over all Actions for a List, newest first, fetching pages as needed
+// - https://developer.atlassian.com/cloud/trello/rest/api-group-lists/#api-lists-id-actions-get
+func (l *List) ActionsIter(ctx context.Context, filter ActionFilter) iter.Seq2[Action, error] {
+	return l.client.actionsIter(ctx, "/lists/"+l.ID+"/actions", filter)
+}
+
 // AddCard creates with the attributes of the supplied Card struct
+// Name, Desc, Pos, Due, Start, DueComplete, IDMembers, IDLabels (or Labels), Address and LocationName are used;
+// see CreateCard for the other creation parameters.
 // https://developers.trello.com/advanced-reference/card#post-1-cards
 func (l *List) AddCard(opts Card) (card *Card, err error) {
-	card = &Card{}
-	opts.IDList = l.ID
+	return l.AddCardContext(context.Background(), opts)
+}
+
+// AddCardContext creates with the attributes of the supplied Card struct (with context)
+func (l *List) AddCardContext(ctx context.Context, opts Card) (card *Card, err error) {
+	create := CreateCardOptions{
+		Name:         opts.Name,
+		Desc:         opts.Desc,
+		Due:          opts.Due,
+		Start:        opts.Start,
+		DueComplete:  opts.DueComplete,
+		IDMembers:    opts.IDMembers,
+		IDLabels:     opts.IDLabels,
+		Address:      opts.Address,
+		LocationName: opts.LocationName,
+	}
+	if opts.Pos != 0.0 {
+		create.Pos = strconv.FormatFloat(opts.Pos, 'g', -1, 64)
+	}
+	for _, label := range opts.Labels {
+		if label.ID != "" && !slices.Contains(create.IDLabels, label.ID) {
+			create.IDLabels = append(create.IDLabels, label.ID)
+		}
+	}
+	return l.CreateCardContext(ctx, create)
+}
 
+// CardProperty - Property of a card kept when it is copied (keepFromSource)
+type CardProperty string
+
+// Card properties
+const (
+	KeepAll          CardProperty = "all"
+	KeepAttachments  CardProperty = "attachments"
+	KeepChecklists   CardProperty = "checklists"
+	KeepComments     CardProperty = "comments"
+	KeepCustomFields CardProperty = "customFields"
+	KeepDue          CardProperty = "due"
+	KeepStart        CardProperty = "start"
+	KeepLabels       CardProperty = "labels"
+	KeepMembers      CardProperty = "members"
+	KeepStickers     CardProperty = "stickers"
+)
+
+// CreateCardOptions - Parameters of a new card, for List.CreateCard
+type CreateCardOptions struct {
+	Name string
+	Desc string
+	// Pos is "top", "bottom" or a positive number ("bottom" if empty)
+	Pos         string
+	Due         *time.Time
+	Start       *time.Time
+	DueComplete bool
+	IDMembers   []string
+	IDLabels    []string
+	// URLSource attaches a link to the new card
+	URLSource string
+	// FileSource is uploaded as an attachment of the new card, named FileName
+	FileSource io.Reader
+	FileName   string
+	MimeType   string
+	// IDCardSource copies an existing card (e.g. a template), keeping KeepFromSource (everything if empty)
+	IDCardSource   string
+	KeepFromSource []CardProperty
+	// Address, LocationName and Coordinates ("latitude,longitude") place the card on the map
+	Address      string
+	LocationName string
+	Coordinates  string
+}
+
+// values - Encode the options as the form of a card creation request
+func (o CreateCardOptions) values() url.Values {
 	payload := url.Values{}
-	payload.Set("name", opts.Name)
-	payload.Set("desc", opts.Desc)
-	if opts.Pos == 0.0 {
+	set := func(key, value string) {
+		if value != "" {
+			payload.Set(key, value)
+		}
+	}
+	set("name", o.Name)
+	set("desc", o.Desc)
+	if o.Pos == "" {
 		payload.Set("pos", "bottom")
 	} else {
-		payload.Set("pos", strconv.FormatFloat(opts.Pos, 'g', -1, 64))
+		payload.Set("pos", o.Pos)
+	}
+	if o.Due != nil {
+		payload.Set("due", o.Due.UTC().Format(time.RFC3339Nano))
 	}
-	payload.Set("due", opts.Due)
-	payload.Set("idList", opts.IDList)
-	payload.Set("idMembers", strings.Join(opts.IDMembers, ","))
+	if o.Start != nil {
+		payload.Set("start", o.Start.UTC().Format(time.RFC3339Nano))
+	}
+	if o.DueComplete {
+		payload.Set("dueComplete", "true")
+	}
+	set("idMembers", strings.Join(o.IDMembers, ","))
+	set("idLabels", strings.Join(o.IDLabels, ","))
+	set("urlSource", o.URLSource)
+	if o.IDCardSource != "" {
+		payload.Set("idCardSource", o.IDCardSource)
+		keep := make([]string, len(o.KeepFromSource))
+		for i, property := range o.KeepFromSource {
+			keep[i] = string(property)
+		}
+		set("keepFromSource", strings.Join(keep, ","))
+	}
+	set("address", o.Address)
+	set("locationName", o.LocationName)
+	set("coordinates", o.Coordinates)
+	return payload
+}
 
-	body, err := l.client.Post("/cards", payload)
+// CreateCard - Create a Card in the List
+// - https://developer.atlassian.com/cloud/trello/rest/api-group-cards/#api-cards-post
+func (l *List) CreateCard(opts CreateCardOptions) (card *Card, err error) {
+	return l.CreateCardContext(context.Background(), opts)
+}
+
+// CreateCardContext - Create a Card in the List (with context)
+// A FileSource is streamed, not buffered in memory.
+func (l *List) CreateCardContext(ctx context.Context, opts CreateCardOptions) (card *Card, err error) {
+	card = &Card{}
+	payload := opts.values()
+	payload.Set("idList", l.ID)
+
+	var body []byte
+	if opts.FileSource != nil {
+		body, err = l.client.postMultipart(ctx, "/cards", payload, "fileSource", opts.FileName, opts.FileSource, opts.MimeType)
+	} else {
+		body, err = l.client.PostContext(ctx, "/cards", payload)
+	}
 	if err == nil {
+		l.cards = nil
 		err = parseCard(body, card, l.client)
 	}
 	return
@@ -93,10 +243,15 @@ func (l *List) AddCard(opts Card) (card *Card, err error) {
 // Archive - Archive List
 //If mode is true, list is archived, otherwise it's unarchived (returns to the board)
 func (l *List) Archive(mode bool) (err error) {
+	return l.ArchiveContext(context.Background(), mode)
+}
+
+// ArchiveContext - Archive List (with context)
+func (l *List) ArchiveContext(ctx context.Context, mode bool) (err error) {
 	payload := url.Values{}
 	payload.Set("value", strconv.FormatBool(mode))
 
-	body, err := l.client.Put("/lists/"+l.ID+"/closed", payload)
+	body, err := l.client.PutContext(ctx, "/lists/"+l.ID+"/closed", payload)
 	if err == nil {
 		err = parseList(body, l, l.client)
 	}
@@ -107,10 +262,15 @@ func (l *List) Archive(mode bool) (err error) {
 // - https://developer.atlassian.com/cloud/trello/rest/api-group-lists/#api-lists-id-put
 //pos can be "bottom", "top" or a positive number
 func (l *List) Move(pos string) (err error) {
+	return l.MoveContext(context.Background(), pos)
+}
+
+// MoveContext - Move a List (with context)
+func (l *List) MoveContext(ctx context.Context, pos string) (err error) {
 	payload := url.Values{}
 	payload.Set("value", pos)
 
-	body, err := l.client.Put("/lists/"+l.ID+"/pos", payload)
+	body, err := l.client.PutContext(ctx, "/lists/"+l.ID+"/pos", payload)
 	if err == nil {
 		err = parseList(body, l, l.client)
 	}
@@ -121,14 +281,26 @@ func parseList(body []byte, list *List, client *Client) (err error) {
 	err = json.Unmarshal(body, &list)
 	if err == nil {
 		list.client = client
+		var nested struct {
+			Cards json.RawMessage `json:"cards"`
+		}
+		if err = json.Unmarshal(body, &nested); err == nil && nested.Cards != nil {
+			list.cards, err = parseListCards(nested.Cards, client)
+		}
 	}
 	return
 }
 
 func parseListLists(body []byte, client *Client) (lists []List, err error) {
-	err = json.Unmarshal(body, &lists)
-	for i := range lists {
-		lists[i].client = client
+	var raw []json.RawMessage
+	err = json.Unmarshal(body, &raw)
+	if err == nil && raw != nil {
+		lists = make([]List, len(raw))
+		for i := range raw {
+			if err = parseList(raw[i], &lists[i], client); err != nil {
+				return nil, err
+			}
+		}
 	}
 	return
 }
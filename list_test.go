@@ -17,7 +17,8 @@ limitations under the License.
 package trello
 
 import (
-	"fmt"
+	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -35,7 +36,7 @@ func TestList(t *testing.T) {
 		var testBoardName string
 
 		g.Before(func() {
-			testBoardName = fmt.Sprintf("GoTestTrello-List-%v", time.Now().Unix())
+			testBoardName = testName("GoTestTrello-List")
 			board, err = client.CreateBoard(testBoardName)
 			Expect(err).To(BeNil())
 			lists, err := board.Lists()
@@ -53,6 +54,28 @@ func TestList(t *testing.T) {
 			Expect(err).To(BeNil())
 		})
 
+		g.It("should iterate over actions for a list", func() {
+			for _, name := range []string{"Iterated 1", "Iterated 2"} {
+				_, err := list.AddCard(Card{Name: name})
+				Expect(err).To(BeNil())
+			}
+			actions, err := list.Actions()
+			Expect(err).To(BeNil())
+			Expect(len(actions)).To(BeNumerically(">=", 2))
+
+			var ids []string
+			for action, err := range list.ActionsIter(context.Background(), ActionFilter{PageSize: 1}) {
+				Expect(err).To(BeNil())
+				ids = append(ids, action.ID)
+			}
+			Expect(len(ids)).To(Equal(len(actions)))
+			for i := range actions {
+				Expect(ids[i]).To(Equal(actions[i].ID))
+			}
+			Expect(actions[0].Type).To(Equal(CreateCard))
+			Expect(actions[0].Data.Card.Name).To(Equal("Iterated 2"))
+		})
+
 		g.It("should add a card to a list", func() {
 			c, err := list.AddCard(Card{
 				Name: "Testing 123",
@@ -72,6 +95,79 @@ func TestList(t *testing.T) {
 			Expect(c).NotTo(BeNil())
 		})
 
+		g.It("should add a card to a list with labels, start and due complete", func() {
+			label, err := board.AddLabel("Urgent", "red")
+			Expect(err).To(BeNil())
+			start := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+			due := start.Add(48 * time.Hour)
+			c, err := list.AddCard(Card{
+				Name:        "Labelled",
+				Labels:      []Label{*label},
+				Start:       &start,
+				Due:         &due,
+				DueComplete: true,
+			})
+			Expect(err).To(BeNil())
+			Expect(c.IDLabels).To(Equal([]string{label.ID}))
+			Expect(c.Start.Equal(start)).To(BeTrue())
+			Expect(c.DueComplete).To(BeTrue())
+		})
+
+		g.It("should create a card with attachments and a location", func() {
+			c, err := list.CreateCard(CreateCardOptions{
+				Name:         "Uploaded",
+				Pos:          "top",
+				FileSource:   strings.NewReader("hello"),
+				FileName:     "hello.txt",
+				MimeType:     "text/plain",
+				Address:      "1 Main Street",
+				LocationName: "Office",
+				Coordinates:  "40.7128,-74.0060",
+			})
+			Expect(err).To(BeNil())
+			Expect(c.Address).To(Equal("1 Main Street"))
+			Expect(c.LocationName).To(Equal("Office"))
+			attachments, err := c.Attachments()
+			Expect(err).To(BeNil())
+			Expect(len(attachments)).To(Equal(1))
+			Expect(attachments[0].Name).To(Equal("hello.txt"))
+
+			cards, err := list.Cards()
+			Expect(err).To(BeNil())
+			Expect(cards[0].ID).To(Equal(c.ID))
+
+			c, err = list.CreateCard(CreateCardOptions{Name: "Linked", URLSource: "https://example.com/spec"})
+			Expect(err).To(BeNil())
+			Expect(c.Badges.Attachments).To(Equal(1))
+
+			_, err = list.CreateCard(CreateCardOptions{Name: "Lost", Coordinates: "north"})
+			Expect(err).NotTo(BeNil())
+		})
+
+		g.It("should create a card from a template card", func() {
+			template, err := list.CreateCard(CreateCardOptions{Name: "Template", Desc: "Steps"})
+			Expect(err).To(BeNil())
+			_, err = template.AddChecklist("Steps")
+			Expect(err).To(BeNil())
+			me, err := client.Member("me")
+			Expect(err).To(BeNil())
+			_, err = template.AddMember(me)
+			Expect(err).To(BeNil())
+
+			c, err := list.CreateCard(CreateCardOptions{
+				Name:           "From template",
+				IDCardSource:   template.ID,
+				KeepFromSource: []CardProperty{KeepChecklists},
+			})
+			Expect(err).To(BeNil())
+			Expect(c.Name).To(Equal("From template"))
+			Expect(c.Desc).To(Equal("Steps"))
+			Expect(c.IDMembers).To(BeEmpty())
+			checklists, err := c.Checklists()
+			Expect(err).To(BeNil())
+			Expect(len(checklists)).To(Equal(1))
+		})
+
 		g.It("should retrieve cards in a list", func() {
 			_, err = list.Cards()
 			Expect(err).To(BeNil())
@@ -17,7 +17,9 @@ limitations under the License.
 package trello
 
 import (
+	"context"
 	"encoding/json"
+	"iter"
 	"strings"
 )
 
@@ -62,9 +64,14 @@ type Member struct {
 
 // Member returns a member (NOTE: "me" defaults to yourself)
 // - https://developer.atlassian.com/cloud/trello/rest/api-group-members/#api-members-id-get
-func (c *Client) Member(nick string) (member *Member, err error) {
+func (c *Client) Member(nick string, opts ...QueryOption) (member *Member, err error) {
+	return c.MemberContext(context.Background(), nick, opts...)
+}
+
+// MemberContext returns a member (with context)
+func (c *Client) MemberContext(ctx context.Context, nick string, opts ...QueryOption) (member *Member, err error) {
 	member = &Member{}
-	body, err := c.Get("/members/" + nick)
+	body, err := c.GetContext(ctx, encodeQuery("/members/"+nick, opts))
 	if err == nil {
 		err = parseMember(body, member, c)
 	}
@@ -74,6 +81,11 @@ func (c *Client) Member(nick string) (member *Member, err error) {
 // Boards returns members boards
 // - https://developer.atlassian.com/cloud/trello/rest/api-group-members/#api-members-id-boards-get
 func (m *Member) Boards(field ...string) (boards []*Board, err error) {
+	return m.BoardsContext(context.Background(), field...)
+}
+
+// BoardsContext returns members boards (with context)
+func (m *Member) BoardsContext(ctx context.Context, field ...string) (boards []*Board, err error) {
 	fields := ""
 	if len(field) == 0 {
 		fields = "all"
@@ -81,7 +93,7 @@ func (m *Member) Boards(field ...string) (boards []*Board, err error) {
 		fields = strings.Join(field, ",")
 	}
 
-	body, err := m.client.Get("/members/" + m.ID + "/boards?fields=" + fields)
+	body, err := m.client.GetContext(ctx, "/members/"+m.ID+"/boards?fields="+fields)
 	if err == nil {
 		boards, err = parseListBoards(body, m.client)
 	}
@@ -91,18 +103,34 @@ func (m *Member) Boards(field ...string) (boards []*Board, err error) {
 // AddBoard creates a new Board
 // - https://developer.atlassian.com/cloud/trello/rest/api-group-boards/#api-boards-post
 func (m *Member) AddBoard(name string) (*Board, error) {
-	return m.client.CreateBoard(name)
+	return m.AddBoardContext(context.Background(), name)
+}
+
+// AddBoardContext creates a new Board (with context)
+func (m *Member) AddBoardContext(ctx context.Context, name string) (*Board, error) {
+	return m.client.CreateBoardContext(ctx, name)
 }
 
 // Notifications - https://developer.atlassian.com/cloud/trello/rest/api-group-members/#api-members-id-notifications-get
 func (m *Member) Notifications() (notifications []Notification, err error) {
-	body, err := m.client.Get("/members/" + m.ID + "/notifications")
+	return m.NotificationsContext(context.Background())
+}
+
+// NotificationsContext - Get Notifications for a Member (with context)
+func (m *Member) NotificationsContext(ctx context.Context) (notifications []Notification, err error) {
+	body, err := m.client.GetContext(ctx, "/members/"+m.ID+"/notifications")
 	if err == nil {
 		notifications, err = parseListNotifications(body, m.client)
 	}
 	return
 }
 
+// ActionsIter - Iterate over all Actions for a Member, newest first, fetching pages as needed
+// - https://developer.atlassian.com/cloud/trello/rest/api-group-members/#api-members-id-actions-get
+func (m *Member) ActionsIter(ctx context.Context, filter ActionFilter) iter.Seq2[Action, error] {
+	return m.client.actionsIter(ctx, "/members/"+m.ID+"/actions", filter)
+}
+
 // AvatarURL returns avatar URL for member
 // TODO: Avatar sizes [170, 30]
 func (m *Member) AvatarURL() string {
@@ -17,9 +17,8 @@ limitations under the License.
 package trello
 
 import (
-	"fmt"
+	"context"
 	"testing"
-	"time"
 
 	goblin "github.com/franela/goblin"
 	. "github.com/onsi/gomega"
@@ -34,7 +33,7 @@ func TestMember(t *testing.T) {
 		var member *Member
 
 		g.Before(func() {
-			testBoardName = fmt.Sprintf("GoTestTrello-Member-%v", time.Now().Unix())
+			testBoardName = testName("GoTestTrello-Member")
 		})
 
 		g.It("should retrieve a member (me)", func() {
@@ -42,6 +41,17 @@ func TestMember(t *testing.T) {
 			Expect(err).To(BeNil())
 		})
 
+		g.It("should iterate over actions for a member", func() {
+			count := 0
+			for action, err := range member.ActionsIter(context.Background(), ActionFilter{PageSize: 5}) {
+				Expect(err).To(BeNil())
+				Expect(action.IDMemberCreator).To(Equal(member.ID))
+				if count++; count == 10 {
+					break
+				}
+			}
+		})
+
 		g.It("should retrieve a member (@trello)", func() {
 			_, err = client.Member("trello")
 			Expect(err).To(BeNil())
@@ -17,6 +17,7 @@ limitations under the License.
 package trello
 
 import (
+	"context"
 	"encoding/json"
 	"net/url"
 )
@@ -37,6 +38,11 @@ type Membership struct {
 // memberType can be admin, normal or observer (if left blank will default to normal)
 // - https://developer.atlassian.com/cloud/trello/rest/api-group-boards/#api-boards-id-memberships-idmembership-put
 func (m *Membership) Update(memberType, memberFields string) (err error) {
+	return m.UpdateContext(context.Background(), memberType, memberFields)
+}
+
+// UpdateContext - Update Membership of Member on a Board (with context)
+func (m *Membership) UpdateContext(ctx context.Context, memberType, memberFields string) (err error) {
 	if memberType == "" {
 		memberType = "normal"
 	}
@@ -45,7 +51,7 @@ func (m *Membership) Update(memberType, memberFields string) (err error) {
 	if memberFields != "" {
 		payload.Set("member_fields", memberFields)
 	}
-	body, err := m.client.Put("/boards/"+m.Board.ID+"/memberships/"+m.ID, payload)
+	body, err := m.client.PutContext(ctx, "/boards/"+m.Board.ID+"/memberships/"+m.ID, payload)
 	if err == nil {
 		err = parseMembership(body, m, m.Board)
 	}
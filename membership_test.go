@@ -17,9 +17,7 @@ limitations under the License.
 package trello
 
 import (
-	"fmt"
 	"testing"
-	"time"
 
 	goblin "github.com/franela/goblin"
 	. "github.com/onsi/gomega"
@@ -36,7 +34,7 @@ func TestMembership(t *testing.T) {
 		var testBoardName string
 
 		g.Before(func() {
-			testBoardName = fmt.Sprintf("GoTestTrello-Membership-%v", time.Now().Unix())
+			testBoardName = testName("GoTestTrello-Membership")
 			board, err = client.CreateBoard(testBoardName)
 			Expect(err).To(BeNil())
 			Expect(board).NotTo(BeNil())
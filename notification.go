@@ -16,15 +16,19 @@ limitations under the License.
 
 package trello
 
-import "encoding/json"
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
 
 // Notification - Trello Notification Type
 type Notification struct {
 	client *Client
-	ID     string `json:"id"`
-	Unread bool   `json:"unread"`
-	Type   string `json:"type"`
-	Date   string `json:"date"`
+	ID     string    `json:"id"`
+	Unread bool      `json:"unread"`
+	Type   string    `json:"type"`
+	Date   time.Time `json:"date"`
 	Data   struct {
 		ListBefore struct {
 			ID   string `json:"id"`
@@ -62,8 +66,13 @@ type Notification struct {
 // Notification - Get Notification by notificationId (string)
 // - https://developer.atlassian.com/cloud/trello/rest/api-group-notifications/#api-notifications-id-get
 func (c *Client) Notification(notificationID string) (notification *Notification, err error) {
+	return c.NotificationContext(context.Background(), notificationID)
+}
+
+// NotificationContext - Get Notification by notificationId (with context)
+func (c *Client) NotificationContext(ctx context.Context, notificationID string) (notification *Notification, err error) {
 	notification = &Notification{}
-	body, err := c.Get("/notifications/" + notificationID)
+	body, err := c.GetContext(ctx, "/notifications/"+notificationID)
 	if err == nil {
 		err = parseNotification(body, notification, c)
 	}
@@ -0,0 +1,117 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trello
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DefaultBaseURL - Trello API endpoint used unless WithBaseURL is given
+const DefaultBaseURL = "https://api.trello.com/1"
+
+// Logger - Minimal logging interface (satisfied by *log.Logger)
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// Option - Functional option for NewClient
+type Option func(*clientConfig) error
+
+type clientConfig struct {
+	httpClient  *http.Client
+	baseURL     string
+	userAgent   string
+	key         string
+	token       *string
+	logger      Logger
+	retryPolicy *RetryPolicy
+	rateLimiter *RateLimiter
+}
+
+// WithBaseURL - Use a different API endpoint (local stub, proxy path, fixture server)
+func WithBaseURL(baseURL string) Option {
+	return func(cfg *clientConfig) error {
+		u, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		if u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("Base URL %q must be an absolute URL", baseURL)
+		}
+		cfg.baseURL = strings.TrimSuffix(baseURL, "/")
+		return nil
+	}
+}
+
+// WithHTTPClient - Use a custom http.Client (default: http.DefaultClient)
+func WithHTTPClient(client *http.Client) Option {
+	return func(cfg *clientConfig) error {
+		if client == nil {
+			return fmt.Errorf("HTTP client must not be nil")
+		}
+		cfg.httpClient = client
+		return nil
+	}
+}
+
+// WithUserAgent - Send a User-Agent header with every request
+func WithUserAgent(userAgent string) Option {
+	return func(cfg *clientConfig) error {
+		cfg.userAgent = userAgent
+		return nil
+	}
+}
+
+// WithAuth - Authenticate every request with the application key and token
+// See https://trello.com/app-key to get your applicationKey
+func WithAuth(applicationKey string, token *string) Option {
+	return func(cfg *clientConfig) error {
+		if token == nil {
+			return fmt.Errorf("Token must not be nil")
+		}
+		cfg.key = applicationKey
+		cfg.token = token
+		return nil
+	}
+}
+
+// WithLogger - Log each request (credentials are never logged)
+func WithLogger(logger Logger) Option {
+	return func(cfg *clientConfig) error {
+		cfg.logger = logger
+		return nil
+	}
+}
+
+// WithRetryPolicy - Retry failed requests according to policy
+func WithRetryPolicy(policy *RetryPolicy) Option {
+	return func(cfg *clientConfig) error {
+		cfg.retryPolicy = policy
+		return nil
+	}
+}
+
+// WithRateLimiter - Throttle requests with limiter (see NewDefaultRateLimiter)
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(cfg *clientConfig) error {
+		cfg.rateLimiter = limiter
+		return nil
+	}
+}
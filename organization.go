@@ -17,6 +17,7 @@ limitations under the License.
 package trello
 
 import (
+	"context"
 	"encoding/json"
 	"net/url"
 )
@@ -40,9 +41,14 @@ type Organization struct {
 
 // Organization - Get Organization by orgId (string)
 // - https://developer.atlassian.com/cloud/trello/rest/api-group-organizations/#api-organizations-id-get
-func (c *Client) Organization(orgID string) (organization *Organization, err error) {
+func (c *Client) Organization(orgID string, opts ...QueryOption) (organization *Organization, err error) {
+	return c.OrganizationContext(context.Background(), orgID, opts...)
+}
+
+// OrganizationContext - Get Organization by orgId (with context)
+func (c *Client) OrganizationContext(ctx context.Context, orgID string, opts ...QueryOption) (organization *Organization, err error) {
 	organization = &Organization{}
-	body, err := c.Get("/organization/" + orgID)
+	body, err := c.GetContext(ctx, encodeQuery("/organization/"+orgID, opts))
 	if err == nil {
 		err = parseOrganization(body, organization, c)
 	}
@@ -52,7 +58,12 @@ func (c *Client) Organization(orgID string) (organization *Organization, err err
 // Members - Get the Members of an Organization
 // - https://developer.atlassian.com/cloud/trello/rest/api-group-organizations/#api-organizations-id-members-get
 func (o *Organization) Members() (members []*Member, err error) {
-	body, err := o.client.Get("/organization/" + o.ID + "/members")
+	return o.MembersContext(context.Background())
+}
+
+// MembersContext - Get the Members of an Organization (with context)
+func (o *Organization) MembersContext(ctx context.Context) (members []*Member, err error) {
+	body, err := o.client.GetContext(ctx, "/organization/"+o.ID+"/members")
 	if err == nil {
 		members, err = parseListMembers(body, o.client)
 	}
@@ -62,7 +73,12 @@ func (o *Organization) Members() (members []*Member, err error) {
 // Boards - Get Boards in an Organization
 // - https://developer.atlassian.com/cloud/trello/rest/api-group-organizations/#api-organizations-id-boards-get
 func (o *Organization) Boards() (boards []*Board, err error) {
-	body, err := o.client.Get("/organizations/" + o.ID + "/boards")
+	return o.BoardsContext(context.Background())
+}
+
+// BoardsContext - Get Boards in an Organization (with context)
+func (o *Organization) BoardsContext(ctx context.Context) (boards []*Board, err error) {
+	body, err := o.client.GetContext(ctx, "/organizations/"+o.ID+"/boards")
 	if err == nil {
 		boards, err = parseListBoards(body, o.client)
 	}
@@ -72,11 +88,16 @@ func (o *Organization) Boards() (boards []*Board, err error) {
 // AddBoard  - Create a new Board in the organization
 // - https://developer.atlassian.com/cloud/trello/rest/api-group-boards/#api-boards-post
 func (o *Organization) AddBoard(name string) (board *Board, err error) {
+	return o.AddBoardContext(context.Background(), name)
+}
+
+// AddBoardContext - Create a new Board in the organization (with context)
+func (o *Organization) AddBoardContext(ctx context.Context, name string) (board *Board, err error) {
 	payload := url.Values{}
 	payload.Set("name", name)
 	payload.Set("idOrganization", o.ID)
 
-	body, err := o.client.Post("/boards", payload)
+	body, err := o.client.PostContext(ctx, "/boards", payload)
 	if err == nil {
 		board = &Board{}
 		err = board.parseBoard(body, o.client)
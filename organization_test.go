@@ -17,10 +17,8 @@ limitations under the License.
 package trello
 
 import (
-	"fmt"
 	"log"
 	"testing"
-	"time"
 
 	goblin "github.com/franela/goblin"
 	. "github.com/onsi/gomega"
@@ -37,7 +35,7 @@ func TestOrganization(t *testing.T) {
 		var Organization *Organization
 
 		g.Before(func() {
-			testBoardName = fmt.Sprintf("GoTestTrello-Organization-%v", time.Now().Unix())
+			testBoardName = testName("GoTestTrello-Organization")
 			member, err = client.Member("me")
 			if err != nil || member == nil {
 				log.Fatal("ERROR Retrieving member (me): " + err.Error())
@@ -0,0 +1,113 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trello
+
+import (
+	"net/url"
+	"strings"
+)
+
+// QueryOption - Query parameter accepted by the getters, selecting fields or nested resources
+// *Argument is also a QueryOption, for parameters without a typed option.
+// - https://developer.atlassian.com/cloud/trello/guides/rest-api/nested-resources/
+type QueryOption interface {
+	setQuery(values url.Values)
+}
+
+type queryOption func(values url.Values)
+
+func (o queryOption) setQuery(values url.Values) {
+	o(values)
+}
+
+func (a *Argument) setQuery(values url.Values) {
+	values.Set(a.Name, a.Value)
+}
+
+// param - QueryOption setting name to value
+func param(name, value string) QueryOption {
+	return queryOption(func(values url.Values) {
+		values.Set(name, value)
+	})
+}
+
+// Fields - Only return the given fields (the ID is always returned)
+func Fields(fields ...string) QueryOption {
+	return param("fields", strings.Join(fields, ","))
+}
+
+// IncludeLists - Include the lists of a board: all, open, closed or none
+func IncludeLists(filter string) QueryOption {
+	return param("lists", filter)
+}
+
+// IncludeCards - Include the cards of a board or list: all, open, closed, visible or none
+func IncludeCards(filter string) QueryOption {
+	return param("cards", filter)
+}
+
+// IncludeChecklists - Include the checklists of a board or card: all or none
+func IncludeChecklists(filter string) QueryOption {
+	return param("checklists", filter)
+}
+
+// IncludeLabels - Include the labels of a board: all or none
+func IncludeLabels(filter string) QueryOption {
+	return param("labels", filter)
+}
+
+// IncludeMembers - Include the members of a board (all, normal, admins, owners or none)
+// or of a card (true or false)
+func IncludeMembers(filter string) QueryOption {
+	return param("members", filter)
+}
+
+// IncludeAttachments - Include the attachments of a card
+func IncludeAttachments() QueryOption {
+	return param("attachments", "true")
+}
+
+// IncludeCustomFieldItems - Include the custom field values of a card
+func IncludeCustomFieldItems() QueryOption {
+	return param("customFieldItems", "true")
+}
+
+// IncludeActions - Include the actions of the given types (all types if none are given)
+func IncludeActions(types ...ActionType) QueryOption {
+	filter := make([]string, len(types))
+	for i, t := range types {
+		filter[i] = string(t)
+	}
+	if len(filter) == 0 {
+		filter = append(filter, "all")
+	}
+	return param("actions", strings.Join(filter, ","))
+}
+
+// encodeQuery - Append the options to resource as a query string
+func encodeQuery(resource string, opts []QueryOption) string {
+	values := url.Values{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt.setQuery(values)
+		}
+	}
+	if query := values.Encode(); query != "" {
+		return resource + "?" + query
+	}
+	return resource
+}
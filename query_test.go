@@ -0,0 +1,222 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trello
+
+import (
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	goblin "github.com/franela/goblin"
+	. "github.com/onsi/gomega"
+)
+
+// countingTransport - Count the requests sent through the delegate
+type countingTransport struct {
+	delegate http.RoundTripper
+	count    int32
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	atomic.AddInt32(&t.count, 1)
+	delegate := t.delegate
+	if delegate == nil {
+		delegate = http.DefaultTransport
+	}
+	return delegate.RoundTrip(req)
+}
+
+func (t *countingTransport) requests() int {
+	return int(atomic.LoadInt32(&t.count))
+}
+
+func TestQuery(t *testing.T) {
+	g := goblin.Goblin(t)
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+
+	g.Describe("Query option tests", func() {
+		var board *Board
+		var card *Card
+		var counter *countingTransport
+		var counted *Client
+
+		g.Before(func() {
+			board, err = client.CreateBoard(testName("GoTestTrello-Query"))
+			Expect(err).To(BeNil())
+			lists, err := board.Lists()
+			Expect(err).To(BeNil())
+			card, err = lists[0].AddCard(Card{Name: "Nested", Desc: "Card with nested resources"})
+			Expect(err).To(BeNil())
+			checklist, err := card.AddChecklist("Nested Checklist")
+			Expect(err).To(BeNil())
+			_, err = checklist.AddItem("Nested Item", "bottom", false)
+			Expect(err).To(BeNil())
+			_, err = card.AddComment("Nested Comment")
+			Expect(err).To(BeNil())
+
+			// Same client, counting the requests it sends
+			counter = &countingTransport{delegate: client.client.Transport}
+			copied := *client
+			copied.client = &http.Client{Transport: counter}
+			counted = &copied
+		})
+
+		g.It("should encode query options", func() {
+			Expect(encodeQuery("/boards/1", nil)).To(Equal("/boards/1"))
+			Expect(encodeQuery("/boards/1", []QueryOption{
+				Fields("name", "desc"),
+				IncludeCards("open"),
+				IncludeActions(CommentCard, CreateCard),
+				NewArgument("filter", "all"),
+			})).To(Equal("/boards/1?actions=commentCard%2CcreateCard&cards=open&fields=name%2Cdesc&filter=all"))
+			Expect(encodeQuery("/cards/1", []QueryOption{IncludeActions(), IncludeAttachments(), IncludeCustomFieldItems()})).
+				To(Equal("/cards/1?actions=all&attachments=true&customFieldItems=true"))
+		})
+
+		g.It("should only return the selected fields", func() {
+			b, err := client.Board(board.ID, Fields("name"))
+			Expect(err).To(BeNil())
+			Expect(b.ID).To(Equal(board.ID))
+			Expect(b.Name).To(Equal(board.Name))
+			Expect(b.URL).To(BeEmpty())
+		})
+
+		g.It("should load nested resources of a board in one request", func() {
+			before := counter.requests()
+			b, err := counted.Board(board.ID,
+				IncludeLists("open"),
+				IncludeCards("open"),
+				IncludeChecklists("all"),
+				IncludeLabels("all"),
+				IncludeMembers("all"),
+				IncludeActions(CommentCard),
+			)
+			Expect(err).To(BeNil())
+
+			lists, err := b.Lists()
+			Expect(err).To(BeNil())
+			Expect(len(lists)).To(Equal(3))
+			Expect(lists[0].client).NotTo(BeNil())
+			cards, err := b.Cards()
+			Expect(err).To(BeNil())
+			Expect(len(cards)).To(Equal(1))
+			Expect(cards[0].Name).To(Equal("Nested"))
+			checklists, err := b.Checklists()
+			Expect(err).To(BeNil())
+			Expect(len(checklists)).To(Equal(1))
+			Expect(checklists[0].client).NotTo(BeNil())
+			Expect(checklists[0].CheckItems[0].client).NotTo(BeNil())
+			labels, err := b.Labels()
+			Expect(err).To(BeNil())
+			Expect(labels).NotTo(BeEmpty())
+			members, err := b.GetMembers()
+			Expect(err).To(BeNil())
+			Expect(members).NotTo(BeEmpty())
+			actions, err := b.Actions()
+			Expect(err).To(BeNil())
+			Expect(len(actions)).To(Equal(1))
+			Expect(actions[0].Type).To(Equal(CommentCard))
+			Expect(counter.requests() - before).To(Equal(1))
+
+			// Options always make a request
+			_, err = b.Actions(NewArgument("filter", "createCard"))
+			Expect(err).To(BeNil())
+			Expect(counter.requests() - before).To(Equal(2))
+		})
+
+		g.It("should load nested resources of a card in one request", func() {
+			before := counter.requests()
+			c, err := counted.Card(card.ID,
+				IncludeChecklists("all"),
+				IncludeMembers("true"),
+				IncludeAttachments(),
+				IncludeCustomFieldItems(),
+				IncludeActions(CommentCard),
+			)
+			Expect(err).To(BeNil())
+
+			checklists, err := c.Checklists()
+			Expect(err).To(BeNil())
+			Expect(len(checklists)).To(Equal(1))
+			Expect(checklists[0].CheckItems[0].Name).To(Equal("Nested Item"))
+			members, err := c.Members()
+			Expect(err).To(BeNil())
+			Expect(members).To(BeEmpty())
+			attachments, err := c.Attachments()
+			Expect(err).To(BeNil())
+			Expect(attachments).To(BeEmpty())
+			items, err := c.CustomFieldItems()
+			Expect(err).To(BeNil())
+			Expect(items).To(BeEmpty())
+			actions, err := c.Actions()
+			Expect(err).To(BeNil())
+			Expect(len(actions)).To(Equal(1))
+			Expect(actions[0].Data.Text).To(Equal("Nested Comment"))
+			Expect(counter.requests() - before).To(Equal(1))
+
+			// Changes drop the loaded resources
+			_, err = c.AddChecklist("Another Checklist")
+			Expect(err).To(BeNil())
+			checklists, err = c.Checklists()
+			Expect(err).To(BeNil())
+			Expect(len(checklists)).To(Equal(2))
+			Expect(counter.requests() - before).To(Equal(3))
+		})
+
+		g.It("should load nested resources of the cards on a board", func() {
+			cards, err := unloadedBoard(counted, board.ID).Cards(IncludeChecklists("all"))
+			Expect(err).To(BeNil())
+			before := counter.requests()
+			checklists, err := cards[0].Checklists()
+			Expect(err).To(BeNil())
+			Expect(len(checklists)).To(Equal(2))
+			Expect(counter.requests()).To(Equal(before))
+		})
+
+		g.It("should load the cards of a list in one request", func() {
+			before := counter.requests()
+			l, err := counted.List(card.IDList, IncludeCards("open"))
+			Expect(err).To(BeNil())
+			cards, err := l.Cards()
+			Expect(err).To(BeNil())
+			Expect(len(cards)).To(Equal(1))
+			Expect(cards[0].client).NotTo(BeNil())
+			Expect(counter.requests() - before).To(Equal(1))
+
+			lists, err := unloadedBoard(counted, board.ID).Lists(IncludeCards("open"))
+			Expect(err).To(BeNil())
+			cards, err = lists[0].Cards()
+			Expect(err).To(BeNil())
+			Expect(len(cards)).To(Equal(1))
+		})
+
+		g.It("should reject invalid nested resource filters", func() {
+			_, err := client.Board(board.ID, IncludeCards("sideways"))
+			Expect(err).NotTo(BeNil())
+		})
+
+		g.After(func() {
+			err = board.Delete()
+			Expect(err).To(BeNil())
+		})
+	})
+}
+
+// unloadedBoard - Board with only the client and ID set, without fetching it
+func unloadedBoard(c *Client, boardID string) *Board {
+	return &Board{client: c, ID: boardID}
+}
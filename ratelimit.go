@@ -0,0 +1,189 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trello
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Trello API limits
+// - https://developer.atlassian.com/cloud/trello/guides/rest-api/rate-limits/
+const (
+	DefaultTokenRateLimit = 100
+	DefaultKeyRateLimit   = 300
+	DefaultRateInterval   = 10 * time.Second
+)
+
+// RateLimitStatus - Remaining request budget for the token and key buckets
+type RateLimitStatus struct {
+	TokenRemaining int
+	TokenMax       int
+	KeyRemaining   int
+	KeyMax         int
+}
+
+// RateLimiter - Token bucket limiter for the per token and per key limits
+// It is safe for concurrent use and adapts to the X-Rate-Limit-* response headers.
+type RateLimiter struct {
+	mu    sync.Mutex
+	token bucket
+	key   bucket
+	now   func() time.Time
+}
+
+type bucket struct {
+	max       int
+	interval  time.Duration
+	remaining float64
+	last      time.Time
+}
+
+// NewRateLimiter - Create a RateLimiter allowing tokenMax (per token) and keyMax (per key)
+// requests every interval
+// A bucket with a non-positive max or interval is unlimited (until response headers set its limits).
+func NewRateLimiter(tokenMax, keyMax int, interval time.Duration) *RateLimiter {
+	r := &RateLimiter{now: time.Now}
+	now := r.now()
+	r.token = bucket{max: tokenMax, interval: interval, remaining: float64(tokenMax), last: now}
+	r.key = bucket{max: keyMax, interval: interval, remaining: float64(keyMax), last: now}
+	return r
+}
+
+// NewDefaultRateLimiter - Create a RateLimiter matching Trello's published limits
+// (100 requests per 10 seconds per token, 300 requests per 10 seconds per key)
+func NewDefaultRateLimiter() *RateLimiter {
+	return NewRateLimiter(DefaultTokenRateLimit, DefaultKeyRateLimit, DefaultRateInterval)
+}
+
+// Wait - Block until a request is allowed by both buckets (or the context is done)
+func (r *RateLimiter) Wait(ctx context.Context) error {
+	for {
+		r.mu.Lock()
+		now := r.now()
+		r.token.refill(now)
+		r.key.refill(now)
+		if r.token.allows() && r.key.allows() {
+			r.token.take()
+			r.key.take()
+			r.mu.Unlock()
+			return nil
+		}
+		delay := r.token.delay()
+		if d := r.key.delay(); d > delay {
+			delay = d
+		}
+		r.mu.Unlock()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Update - Adapt the buckets to the X-Rate-Limit-* headers of a response
+// A 429 response without headers empties both buckets.
+func (r *RateLimiter) Update(resp *http.Response) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.now()
+	tokenSeen := r.token.update(resp.Header, "X-Rate-Limit-Api-Token-", now)
+	keySeen := r.key.update(resp.Header, "X-Rate-Limit-Api-Key-", now)
+	if resp.StatusCode == http.StatusTooManyRequests && !tokenSeen && !keySeen {
+		r.token.remaining, r.token.last = 0, now
+		r.key.remaining, r.key.last = 0, now
+	}
+}
+
+// Remaining - Return the current request budget
+func (r *RateLimiter) Remaining() RateLimitStatus {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.now()
+	r.token.refill(now)
+	r.key.refill(now)
+	return RateLimitStatus{
+		TokenRemaining: int(r.token.remaining),
+		TokenMax:       r.token.max,
+		KeyRemaining:   int(r.key.remaining),
+		KeyMax:         r.key.max,
+	}
+}
+
+// unlimited - True if the bucket has no usable limits
+func (b *bucket) unlimited() bool {
+	return b.max <= 0 || b.interval <= 0
+}
+
+// allows - True if the bucket holds a full request
+func (b *bucket) allows() bool {
+	return b.unlimited() || b.remaining >= 1
+}
+
+// take - Consume a request from the bucket
+func (b *bucket) take() {
+	if !b.unlimited() {
+		b.remaining--
+	}
+}
+
+func (b *bucket) refill(now time.Time) {
+	elapsed := now.Sub(b.last)
+	if elapsed <= 0 || b.unlimited() {
+		return
+	}
+	b.remaining += float64(b.max) * float64(elapsed) / float64(b.interval)
+	if b.remaining > float64(b.max) {
+		b.remaining = float64(b.max)
+	}
+	b.last = now
+}
+
+// delay - Time until the bucket holds a full request
+func (b *bucket) delay() time.Duration {
+	if b.allows() {
+		return 0
+	}
+	return time.Duration((1 - b.remaining) * float64(b.interval) / float64(b.max))
+}
+
+// update - Apply the headers with the given prefix, returning true if any were present
+func (b *bucket) update(header http.Header, prefix string, now time.Time) (seen bool) {
+	// Credit the time elapsed so far at the current rate before last moves
+	b.refill(now)
+	if v, err := strconv.Atoi(header.Get(prefix + "Max")); err == nil && v > 0 {
+		b.max, seen = v, true
+	}
+	if v, err := strconv.Atoi(header.Get(prefix + "Interval-Ms")); err == nil && v > 0 {
+		b.interval, seen = time.Duration(v)*time.Millisecond, true
+	}
+	if v, err := strconv.Atoi(header.Get(prefix + "Remaining")); err == nil && v >= 0 {
+		// Other clients may share the key, so the server count wins if it is lower
+		if float64(v) < b.remaining {
+			b.remaining = float64(v)
+		}
+		b.last, seen = now, true
+	}
+	return
+}
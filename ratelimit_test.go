@@ -0,0 +1,116 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trello
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	goblin "github.com/franela/goblin"
+	. "github.com/onsi/gomega"
+)
+
+func TestRateLimiter(t *testing.T) {
+	g := goblin.Goblin(t)
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+
+	g.Describe("RateLimiter tests", func() {
+		var limiter *RateLimiter
+		var now time.Time
+
+		g.BeforeEach(func() {
+			now = time.Now()
+			limiter = NewRateLimiter(2, 3, 10*time.Second)
+			limiter.now = func() time.Time { return now }
+			limiter.token.last = now
+			limiter.key.last = now
+		})
+
+		g.It("should default to the Trello limits", func() {
+			status := NewDefaultRateLimiter().Remaining()
+			Expect(status.TokenMax).To(Equal(100))
+			Expect(status.KeyMax).To(Equal(300))
+		})
+
+		g.It("should consume the budget and block when empty", func() {
+			Expect(limiter.Wait(context.Background())).To(BeNil())
+			Expect(limiter.Wait(context.Background())).To(BeNil())
+			Expect(limiter.Remaining().TokenRemaining).To(Equal(0))
+			Expect(limiter.Remaining().KeyRemaining).To(Equal(1))
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+			Expect(limiter.Wait(ctx)).To(Equal(context.DeadlineExceeded))
+		})
+
+		g.It("should refill over time", func() {
+			Expect(limiter.Wait(context.Background())).To(BeNil())
+			Expect(limiter.Wait(context.Background())).To(BeNil())
+			now = now.Add(5 * time.Second)
+			Expect(limiter.Remaining().TokenRemaining).To(Equal(1))
+		})
+
+		g.It("should adapt to the rate limit headers", func() {
+			resp := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}
+			resp.Header.Set("X-Rate-Limit-Api-Token-Max", "50")
+			resp.Header.Set("X-Rate-Limit-Api-Token-Interval-Ms", "1000")
+			resp.Header.Set("X-Rate-Limit-Api-Token-Remaining", "1")
+			limiter.Update(resp)
+			status := limiter.Remaining()
+			Expect(status.TokenMax).To(Equal(50))
+			Expect(status.TokenRemaining).To(Equal(1))
+			Expect(status.KeyRemaining).To(Equal(3))
+		})
+
+		g.It("should keep the refill elapsed before the headers", func() {
+			Expect(limiter.Wait(context.Background())).To(BeNil())
+			Expect(limiter.Wait(context.Background())).To(BeNil())
+			now = now.Add(5 * time.Second)
+			resp := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}
+			resp.Header.Set("X-Rate-Limit-Api-Token-Remaining", "2")
+			limiter.Update(resp)
+			Expect(limiter.Remaining().TokenRemaining).To(Equal(1))
+		})
+
+		g.It("should not limit buckets without a max or interval", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			for _, unlimited := range []*RateLimiter{NewRateLimiter(0, 300, time.Second), NewRateLimiter(300, -1, time.Second), NewRateLimiter(2, 3, 0)} {
+				for i := 0; i < 10; i++ {
+					Expect(unlimited.Wait(ctx)).To(BeNil())
+				}
+			}
+		})
+
+		g.It("should empty the buckets on a 429 without headers", func() {
+			limiter.Update(&http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}})
+			status := limiter.Remaining()
+			Expect(status.TokenRemaining).To(Equal(0))
+			Expect(status.KeyRemaining).To(Equal(0))
+		})
+
+		g.It("should be used by the client", func() {
+			c, err := NewCustomClient(http.DefaultClient)
+			Expect(err).To(BeNil())
+			Expect(c.RateLimiter()).To(BeNil())
+			c.SetRateLimiter(limiter)
+			Expect(c.RateLimiter()).To(Equal(limiter))
+		})
+	})
+}
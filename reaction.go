@@ -0,0 +1,108 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trello
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+)
+
+// Reaction - Emoji reaction of a member to a comment
+type Reaction struct {
+	client   *Client
+	ID       string `json:"id"`
+	IDMember string `json:"idMember"`
+	IDModel  string `json:"idModel"`
+	IDEmoji  string `json:"idEmoji"`
+	Member   struct {
+		ID       string `json:"id"`
+		FullName string `json:"fullName"`
+		Username string `json:"username"`
+	} `json:"member"`
+	Emoji Emoji `json:"emoji"`
+}
+
+// Emoji - Emoji of a Reaction
+type Emoji struct {
+	Unified       string `json:"unified"`
+	Native        string `json:"native"`
+	Name          string `json:"name"`
+	ShortName     string `json:"shortName"`
+	SkinVariation string `json:"skinVariation,omitempty"`
+}
+
+// Reactions - Get the Reactions to a comment
+// - https://developer.atlassian.com/cloud/trello/rest/api-group-actions/#api-actions-idaction-reactions-get
+func (a *Action) Reactions() (reactions []Reaction, err error) {
+	return a.ReactionsContext(context.Background())
+}
+
+// ReactionsContext - Get the Reactions to a comment (with context)
+func (a *Action) ReactionsContext(ctx context.Context) (reactions []Reaction, err error) {
+	body, err := a.client.GetContext(ctx, "/actions/"+a.ID+"/reactions?member=true&emoji=true")
+	if err == nil {
+		reactions, err = parseListReactions(body, a.client)
+	}
+	return
+}
+
+// AddReaction - React to a comment with an emoji, by short name (e.g. "thumbsup")
+// - https://developer.atlassian.com/cloud/trello/rest/api-group-actions/#api-actions-idaction-reactions-post
+func (a *Action) AddReaction(shortName string) (reaction *Reaction, err error) {
+	return a.AddReactionContext(context.Background(), shortName)
+}
+
+// AddReactionContext - React to a comment with an emoji (with context)
+func (a *Action) AddReactionContext(ctx context.Context, shortName string) (reaction *Reaction, err error) {
+	reaction = &Reaction{}
+	payload := url.Values{}
+	payload.Set("shortName", shortName)
+	body, err := a.client.PostContext(ctx, "/actions/"+a.ID+"/reactions", payload)
+	if err == nil {
+		err = parseReaction(body, reaction, a.client)
+	}
+	return
+}
+
+// Delete - Remove a Reaction
+// - https://developer.atlassian.com/cloud/trello/rest/api-group-actions/#api-actions-idaction-reactions-id-delete
+func (r *Reaction) Delete() (err error) {
+	return r.DeleteContext(context.Background())
+}
+
+// DeleteContext - Remove a Reaction (with context)
+func (r *Reaction) DeleteContext(ctx context.Context) (err error) {
+	_, err = r.client.DeleteContext(ctx, "/actions/"+r.IDModel+"/reactions/"+r.ID)
+	return
+}
+
+func parseReaction(body []byte, reaction *Reaction, client *Client) (err error) {
+	err = json.Unmarshal(body, &reaction)
+	if err == nil {
+		reaction.client = client
+	}
+	return
+}
+
+func parseListReactions(body []byte, client *Client) (reactions []Reaction, err error) {
+	err = json.Unmarshal(body, &reactions)
+	for i := range reactions {
+		reactions[i].client = client
+	}
+	return
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trello
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy - Controls how requests that fail with 429 or transient 5xx responses are retried
+// Only idempotent methods (GET, HEAD, PUT, DELETE) are retried unless RetryPost is set.
+type RetryPolicy struct {
+	MaxAttempts int           // Total attempts, including the first request
+	BaseDelay   time.Duration // Delay before the first retry, doubled on each attempt
+	MaxDelay    time.Duration // Upper bound for any single delay (including Retry-After)
+	RetryPost   bool          // Also retry POST requests (which may not be idempotent)
+}
+
+// NewDefaultRetryPolicy - Create a RetryPolicy with 4 attempts and 500ms..30s backoff
+func NewDefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 4,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    30 * time.Second,
+	}
+}
+
+// next - Decide whether the attempt should be retried and how long to wait first
+func (p *RetryPolicy) next(req *http.Request, resp *http.Response, err error, attempt int) (delay time.Duration, retry bool) {
+	if p == nil || attempt >= p.MaxAttempts || resp == nil || err == nil {
+		return 0, false
+	}
+	if !retryableStatus(resp.StatusCode) || !p.retryableMethod(req.Method) {
+		return 0, false
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return 0, false
+	}
+	if d, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+		delay = d
+	} else {
+		delay = p.backoff(attempt)
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	return delay, true
+}
+
+// backoff - Exponential backoff with jitter (between half and all of the computed delay)
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.BaseDelay << uint(attempt-1)
+	if d <= 0 || (p.MaxDelay > 0 && d > p.MaxDelay) {
+		d = p.MaxDelay
+	}
+	if d <= 0 {
+		return 0
+	}
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(d-half)+1))
+}
+
+func (p *RetryPolicy) retryableMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	case http.MethodPost:
+		return p.RetryPost
+	}
+	return false
+}
+
+func retryableStatus(status int) bool {
+	return status == http.StatusTooManyRequests || (status >= 500 && status != http.StatusNotImplemented)
+}
+
+// retryAfter - Parse a Retry-After header (delay in seconds or an HTTP date)
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trello
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	goblin "github.com/franela/goblin"
+	. "github.com/onsi/gomega"
+)
+
+func TestRetry(t *testing.T) {
+	g := goblin.Goblin(t)
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+
+	g.Describe("Retry tests", func() {
+		var server *httptest.Server
+		var retryClient *Client
+		var calls int32
+		var failures int32
+		var status int
+		var bodies []string
+
+		g.BeforeEach(func() {
+			atomic.StoreInt32(&calls, 0)
+			failures = 2
+			status = http.StatusServiceUnavailable
+			bodies = nil
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := ioutil.ReadAll(r.Body)
+				bodies = append(bodies, string(body))
+				if atomic.AddInt32(&calls, 1) <= failures {
+					w.Header().Set("Retry-After", "0")
+					w.WriteHeader(status)
+					return
+				}
+				w.Write([]byte(`{"id":"abc","name":"retried"}`))
+			}))
+			retryClient, _ = NewCustomClient(server.Client())
+			retryClient.endpoint = server.URL
+			retryClient.SetRetryPolicy(&RetryPolicy{
+				MaxAttempts: 3,
+				BaseDelay:   time.Millisecond,
+				MaxDelay:    10 * time.Millisecond,
+			})
+		})
+
+		g.AfterEach(func() {
+			server.Close()
+		})
+
+		g.It("should retry a GET on 5xx until it succeeds", func() {
+			board, err := retryClient.Board("abc")
+			Expect(err).To(BeNil())
+			Expect(board.Name).To(Equal("retried"))
+			Expect(atomic.LoadInt32(&calls)).To(Equal(int32(3)))
+		})
+
+		g.It("should retry a GET on 429", func() {
+			status = http.StatusTooManyRequests
+			_, err := retryClient.Board("abc")
+			Expect(err).To(BeNil())
+			Expect(atomic.LoadInt32(&calls)).To(Equal(int32(3)))
+		})
+
+		g.It("should give up after MaxAttempts", func() {
+			failures = 5
+			_, err := retryClient.Board("abc")
+			Expect(errors.Is(err, ErrRateLimited)).To(BeFalse())
+			var apiErr *APIError
+			Expect(errors.As(err, &apiErr)).To(BeTrue())
+			Expect(apiErr.StatusCode).To(Equal(http.StatusServiceUnavailable))
+			Expect(atomic.LoadInt32(&calls)).To(Equal(int32(3)))
+		})
+
+		g.It("should not retry a 4xx", func() {
+			status = http.StatusNotFound
+			_, err := retryClient.Board("abc")
+			Expect(errors.Is(err, ErrNotFound)).To(BeTrue())
+			Expect(atomic.LoadInt32(&calls)).To(Equal(int32(1)))
+		})
+
+		g.It("should not retry a POST by default", func() {
+			_, err := retryClient.CreateBoard("test")
+			Expect(err).NotTo(BeNil())
+			Expect(atomic.LoadInt32(&calls)).To(Equal(int32(1)))
+		})
+
+		g.It("should retry a POST (with the same body) when opted in", func() {
+			retryClient.RetryPolicy().RetryPost = true
+			_, err := retryClient.CreateBoard("test")
+			Expect(err).To(BeNil())
+			Expect(atomic.LoadInt32(&calls)).To(Equal(int32(3)))
+			expected := url.Values{"name": {"test"}}.Encode()
+			Expect(bodies).To(Equal([]string{expected, expected, expected}))
+		})
+
+		g.It("should not retry without a policy", func() {
+			retryClient.SetRetryPolicy(nil)
+			_, err := retryClient.Board("abc")
+			Expect(err).NotTo(BeNil())
+			Expect(atomic.LoadInt32(&calls)).To(Equal(int32(1)))
+		})
+
+		g.It("should parse Retry-After values", func() {
+			d, ok := retryAfter("2")
+			Expect(ok).To(BeTrue())
+			Expect(d).To(Equal(2 * time.Second))
+			_, ok = retryAfter(time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))
+			Expect(ok).To(BeTrue())
+			_, ok = retryAfter("soon")
+			Expect(ok).To(BeFalse())
+		})
+
+		g.It("should cap backoff at MaxDelay", func() {
+			p := NewDefaultRetryPolicy()
+			for attempt := 1; attempt < 100; attempt++ {
+				Expect(p.backoff(attempt)).To(BeNumerically("<=", p.MaxDelay))
+			}
+		})
+	})
+}
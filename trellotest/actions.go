@@ -0,0 +1,264 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trellotest
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Trello caps the number of actions returned by a single request
+const maxActionLimit = 1000
+
+// addAction - Record an action performed by "me"
+func (s *Server) addAction(actionType string, data map[string]interface{}) *action {
+	me := s.me()
+	a := &action{
+		ID:              s.newID(),
+		IDMemberCreator: me.ID,
+		Type:            actionType,
+		Date:            s.date(),
+		Data:            data,
+		MemberCreator:   me.creator(),
+	}
+	s.actions = append(s.actions, a)
+	return a
+}
+
+func (s *Server) action(id string) (*action, error) {
+	if err := validID(id); err != nil {
+		return nil, err
+	}
+	for _, a := range s.actions {
+		if a.ID == id {
+			return a, nil
+		}
+	}
+	return nil, errNotFound()
+}
+
+// listActions - Render the actions matching pred, honoring the filter, since,
+// before and limit parameters (newest first, as Trello does)
+func (s *Server) listActions(r *http.Request, pred func(*action) bool) (interface{}, error) {
+	filter := splitIDs(r.Form.Get("filter"))
+	if len(filter) == 1 && filter[0] == "all" {
+		filter = nil
+	}
+	limit := 50
+	if v := r.Form.Get("limit"); v != "" {
+		l, err := strconv.Atoi(v)
+		if err != nil || l < 0 || l > maxActionLimit {
+			return nil, errInvalid("value for limit")
+		}
+		limit = l
+	}
+	before, err := actionCursor(r.Form.Get("before"))
+	if err != nil {
+		return nil, err
+	}
+	since, err := actionCursor(r.Form.Get("since"))
+	if err != nil {
+		return nil, err
+	}
+
+	actions := []*action{}
+	for i := len(s.actions) - 1; i >= 0 && len(actions) < limit; i-- {
+		a := s.actions[i]
+		if !pred(a) || (len(filter) > 0 && !contains(filter, a.Type)) {
+			continue
+		}
+		if before != nil && !before(a, true) {
+			continue
+		}
+		if since != nil && !since(a, false) {
+			continue
+		}
+		actions = append(actions, a)
+	}
+	return actions, nil
+}
+
+// actionCursor - Parse a before/since value (an action ID or a date) into a comparison
+func actionCursor(value string) (func(a *action, before bool) bool, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if idPattern.MatchString(value) {
+		return func(a *action, before bool) bool {
+			if before {
+				return a.ID < value
+			}
+			return a.ID > value
+		}, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return nil, errInvalid("date")
+	}
+	return func(a *action, before bool) bool {
+		date, _ := time.Parse(time.RFC3339Nano, a.Date)
+		if before {
+			return date.Before(t)
+		}
+		return date.After(t)
+	}, nil
+}
+
+// dataID - ID of the model (board, list, card...) referenced by the action data
+func dataID(a *action, model string) string {
+	if ref, ok := a.Data[model].(map[string]interface{}); ok {
+		if id, ok := ref["id"].(string); ok {
+			return id
+		}
+	}
+	return ""
+}
+
+func boardRef(b *board) map[string]interface{} {
+	return map[string]interface{}{"id": b.ID, "name": b.Name, "shortLink": b.ShortLink}
+}
+
+func listRef(l *list) map[string]interface{} {
+	return map[string]interface{}{"id": l.ID, "name": l.Name}
+}
+
+func cardRef(c *card) map[string]interface{} {
+	return map[string]interface{}{"id": c.ID, "name": c.Name, "idShort": c.IDShort, "shortLink": c.ShortLink}
+}
+
+func memberRef(m *member) map[string]interface{} {
+	return map[string]interface{}{"id": m.ID, "name": m.FullName}
+}
+
+// cardData - Action data referencing a card, its list and its board
+func (s *Server) cardData(c *card) map[string]interface{} {
+	data := map[string]interface{}{"card": cardRef(c)}
+	if l, ok := s.lists[c.IDList]; ok {
+		data["list"] = listRef(l)
+	}
+	if b, ok := s.boards[c.IDBoard]; ok {
+		data["board"] = boardRef(b)
+	}
+	return data
+}
+
+func (s *Server) routeActions(r *http.Request, seg []string) (interface{}, error) {
+	if len(seg) == 0 {
+		return nil, errCannot(r)
+	}
+	a, err := s.action(seg[0])
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case len(seg) == 1 && r.Method == http.MethodGet:
+		return a, nil
+	case len(seg) == 1 && r.Method == http.MethodPut:
+		return s.editComment(a, r.Form.Get("text"))
+	case len(seg) == 2 && seg[1] == "text" && r.Method == http.MethodPut:
+		return s.editComment(a, r.Form.Get("value"))
+	case len(seg) == 1 && r.Method == http.MethodDelete:
+		if a.Type != "commentCard" {
+			return nil, errInvalid("action: only comments can be deleted")
+		}
+		for i, other := range s.actions {
+			if other == a {
+				s.actions = append(s.actions[:i], s.actions[i+1:]...)
+				break
+			}
+		}
+		return map[string]interface{}{"_value": nil}, nil
+	case len(seg) >= 2 && seg[1] == "reactions":
+		return s.reactionsRoute(r, a, seg[2:])
+	}
+	return nil, errCannot(r)
+}
+
+func (s *Server) editComment(a *action, text string) (interface{}, error) {
+	if a.Type != "commentCard" {
+		return nil, errInvalid("action: only comments can be edited")
+	}
+	if text == "" {
+		return nil, errInvalid("value for text")
+	}
+	a.Data["text"] = text
+	a.Data["dateLastEdited"] = s.date()
+	return a, nil
+}
+
+// knownEmoji - The emoji the fake can react with, by short name
+var knownEmoji = map[string]emoji{
+	"thumbsup":         {Unified: "1F44D", Native: "\U0001F44D", Name: "THUMBS UP SIGN", ShortName: "thumbsup"},
+	"thumbsdown":       {Unified: "1F44E", Native: "\U0001F44E", Name: "THUMBS DOWN SIGN", ShortName: "thumbsdown"},
+	"white_check_mark": {Unified: "2705", Native: "\u2705", Name: "WHITE HEAVY CHECK MARK", ShortName: "white_check_mark"},
+	"x":                {Unified: "274C", Native: "\u274C", Name: "CROSS MARK", ShortName: "x"},
+	"eyes":             {Unified: "1F440", Native: "\U0001F440", Name: "EYES", ShortName: "eyes"},
+	"tada":             {Unified: "1F389", Native: "\U0001F389", Name: "PARTY POPPER", ShortName: "tada"},
+}
+
+func (s *Server) reactionsRoute(r *http.Request, a *action, seg []string) (interface{}, error) {
+	if a.Type != "commentCard" {
+		return nil, errInvalid("action: only comments have reactions")
+	}
+	switch {
+	case len(seg) == 0 && r.Method == http.MethodGet:
+		withMember, err := parseBool(r.Form.Get("member"), true)
+		if err != nil {
+			return nil, err
+		}
+		withEmoji, err := parseBool(r.Form.Get("emoji"), true)
+		if err != nil {
+			return nil, err
+		}
+		reactions := []*reaction{}
+		for _, re := range a.reactions {
+			rendered := *re
+			if !withMember {
+				rendered.Member = nil
+			}
+			if !withEmoji {
+				rendered.Emoji = nil
+			}
+			reactions = append(reactions, &rendered)
+		}
+		return reactions, nil
+	case len(seg) == 0 && r.Method == http.MethodPost:
+		e, ok := knownEmoji[r.Form.Get("shortName")]
+		if !ok {
+			return nil, errInvalid("value for shortName")
+		}
+		me := s.me()
+		for _, re := range a.reactions {
+			if re.IDMember == me.ID && re.IDEmoji == e.Unified {
+				return nil, errInvalid("reaction already exists")
+			}
+		}
+		re := &reaction{ID: s.newID(), IDMember: me.ID, IDModel: a.ID, IDEmoji: e.Unified, Member: me.creator(), Emoji: &e}
+		a.reactions = append(a.reactions, re)
+		return re, nil
+	case len(seg) == 1 && r.Method == http.MethodDelete:
+		for i, re := range a.reactions {
+			if re.ID == seg[0] {
+				a.reactions = append(a.reactions[:i], a.reactions[i+1:]...)
+				return map[string]interface{}{"_value": nil}, nil
+			}
+		}
+		return nil, errNotFound()
+	}
+	return nil, errCannot(r)
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trellotest
+
+import (
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+)
+
+// Largest upload accepted (Trello allows 10MB without a paid plan)
+const maxUpload = 10 << 20
+
+// rawResponse - Response written as is instead of being encoded as JSON
+type rawResponse struct {
+	contentType string
+	body        []byte
+}
+
+func (s *Server) cardAttachments(c *card) []*attachment {
+	attachments := []*attachment{}
+	for _, a := range s.attachments {
+		if a.idCard == c.ID {
+			attachments = append(attachments, a)
+		}
+	}
+	sort.Slice(attachments, func(i, j int) bool { return attachments[i].Pos < attachments[j].Pos })
+	return attachments
+}
+
+func (s *Server) cardAttachment(c *card, id string) (*attachment, error) {
+	if err := validID(id); err != nil {
+		return nil, err
+	}
+	if a, ok := s.attachments[id]; ok && a.idCard == c.ID {
+		return a, nil
+	}
+	return nil, errNotFound()
+}
+
+// addAttachment - Attach a copy of a to c
+func (s *Server) addAttachment(c *card, a attachment) *attachment {
+	a.ID = s.newID()
+	a.idCard = c.ID
+	a.IDMember = s.meID
+	a.Date = s.date()
+	a.Previews = []interface{}{}
+	positions := []float64{}
+	for _, other := range s.cardAttachments(c) {
+		positions = append(positions, other.Pos)
+	}
+	a.Pos, _ = nextPos(positions, "bottom")
+	if a.IsUpload {
+		a.URL = s.URL + "/1/cards/" + c.ID + "/attachments/" + a.ID + "/download/" + url.PathEscape(a.FileName)
+	}
+	s.attachments[a.ID] = &a
+	return &a
+}
+
+func (s *Server) deleteAttachment(c *card, a *attachment) {
+	delete(s.attachments, a.ID)
+	if c.IDAttachmentCover != nil && *c.IDAttachmentCover == a.ID {
+		c.IDAttachmentCover = nil
+	}
+}
+
+// formAttachment - Attachment described by the file upload in field or by the link in urlField
+func formAttachment(r *http.Request, field, urlField string) (a attachment, err error) {
+	if r.MultipartForm != nil && len(r.MultipartForm.File[field]) > 0 {
+		header := r.MultipartForm.File[field][0]
+		file, err := header.Open()
+		if err != nil {
+			return a, errInvalid(field)
+		}
+		defer file.Close()
+		if a.data, err = ioutil.ReadAll(file); err != nil {
+			return a, errInvalid(field)
+		}
+		bytes := len(a.data)
+		a.Bytes = &bytes
+		a.IsUpload = true
+		a.FileName = header.Filename
+		a.Name = header.Filename
+		a.MimeType = r.Form.Get("mimeType")
+		if a.MimeType == "" {
+			a.MimeType = header.Header.Get("Content-Type")
+		}
+		return a, nil
+	}
+	if err := validCallbackURL(r.Form.Get(urlField)); err != nil {
+		return a, errInvalid("value for " + urlField)
+	}
+	a.URL = r.Form.Get(urlField)
+	a.Name = a.URL
+	a.FileName = path.Base(a.URL)
+	a.MimeType = r.Form.Get("mimeType")
+	return a, nil
+}
+
+func (s *Server) postAttachment(r *http.Request, c *card) (interface{}, error) {
+	a, err := formAttachment(r, "file", "url")
+	if err != nil {
+		return nil, err
+	}
+	if name := r.Form.Get("name"); name != "" {
+		a.Name = name
+	}
+	created := s.addAttachment(c, a)
+	if cover, err := parseBool(r.Form.Get("setCover"), false); err != nil {
+		return nil, err
+	} else if cover && created.IsUpload && strings.HasPrefix(created.MimeType, "image/") {
+		c.IDAttachmentCover = &created.ID
+	}
+	data := s.cardData(c)
+	data["attachment"] = map[string]interface{}{"id": created.ID, "name": created.Name, "url": created.URL}
+	s.addAction("addAttachmentToCard", data)
+	return created, nil
+}
+
+func (s *Server) cardAttachmentsRoute(r *http.Request, c *card, seg []string) (interface{}, error) {
+	switch {
+	case len(seg) == 0 && r.Method == http.MethodGet:
+		return s.cardAttachments(c), nil
+	case len(seg) == 0 && r.Method == http.MethodPost:
+		return s.postAttachment(r, c)
+	}
+	a, err := s.cardAttachment(c, seg[0])
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case len(seg) == 1 && r.Method == http.MethodGet:
+		return a, nil
+	case len(seg) == 1 && r.Method == http.MethodDelete:
+		s.deleteAttachment(c, a)
+		data := s.cardData(c)
+		data["attachment"] = map[string]interface{}{"id": a.ID, "name": a.Name}
+		s.addAction("deleteAttachmentFromCard", data)
+		return map[string]interface{}{"_value": nil}, nil
+	case len(seg) == 3 && seg[1] == "download" && r.Method == http.MethodGet:
+		if !a.IsUpload || seg[2] != a.FileName {
+			return nil, errNotFound()
+		}
+		if err := s.checkOAuth(r); err != nil {
+			return nil, err
+		}
+		return &rawResponse{contentType: a.MimeType, body: a.data}, nil
+	}
+	return nil, errCannot(r)
+}
+
+// checkOAuth - Downloads need the key and token in an OAuth Authorization header
+func (s *Server) checkOAuth(r *http.Request) error {
+	if s.Key == "" && s.Token == "" {
+		return nil
+	}
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "OAuth ") {
+		return &httpError{http.StatusUnauthorized, "missing OAuth authorization header"}
+	}
+	_, params, err := mime.ParseMediaType("oauth; " + strings.ReplaceAll(strings.TrimPrefix(auth, "OAuth "), ",", ";"))
+	if err != nil || params["oauth_consumer_key"] != s.Key || params["oauth_token"] != s.Token {
+		return &httpError{http.StatusUnauthorized, "invalid OAuth authorization header"}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trellotest
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Trello accepts at most this many routes in a batch request
+const maxBatchURLs = 10
+
+// routeBatch - Run each GET route in urls and wrap its result as {"200": result}
+// or {"<status>": message}
+func (s *Server) routeBatch(r *http.Request, seg []string) (interface{}, error) {
+	if len(seg) != 0 || r.Method != http.MethodGet {
+		return nil, errCannot(r)
+	}
+	urls := splitIDs(r.Form.Get("urls"))
+	if len(urls) == 0 || len(urls) > maxBatchURLs {
+		return nil, errInvalid("value for urls")
+	}
+
+	results := []interface{}{}
+	for _, u := range urls {
+		sub, err := batchRequest(r, u)
+		var result interface{}
+		if err == nil {
+			result, err = s.route(sub)
+		}
+		if err != nil {
+			status := http.StatusInternalServerError
+			if he, ok := err.(*httpError); ok {
+				status = he.status
+			}
+			results = append(results, map[string]interface{}{strconv.Itoa(status): err.Error()})
+			continue
+		}
+		results = append(results, map[string]interface{}{"200": result})
+	}
+	return results, nil
+}
+
+// batchRequest - Build the GET request for a batch route, keeping the batch credentials
+func batchRequest(r *http.Request, route string) (*http.Request, error) {
+	if !strings.HasPrefix(route, "/") {
+		return nil, errInvalid("url")
+	}
+	u, err := url.Parse("/1" + route)
+	if err != nil {
+		return nil, errInvalid("url")
+	}
+	query := u.Query()
+	for _, name := range []string{"key", "token"} {
+		if value := r.Form.Get(name); value != "" {
+			query.Set(name, value)
+		}
+	}
+	u.RawQuery = query.Encode()
+	sub := r.Clone(r.Context())
+	sub.Method = http.MethodGet
+	sub.URL = u
+	sub.Body = nil
+	sub.Form = nil
+	sub.PostForm = nil
+	return sub, nil
+}
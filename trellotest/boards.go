@@ -0,0 +1,474 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trellotest
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+)
+
+var (
+	defaultLists       = []string{"To Do", "Doing", "Done"}
+	defaultLabelColors = []string{"green", "yellow", "orange", "red", "purple", "blue"}
+)
+
+func (b *board) membership(memberID string) *membership {
+	for _, ms := range b.Memberships {
+		if ms.IDMember == memberID {
+			return ms
+		}
+	}
+	return nil
+}
+
+func (s *Server) board(id string) (*board, error) {
+	if b, ok := s.boards[id]; ok {
+		return b, nil
+	}
+	for _, b := range s.boards {
+		if b.ShortLink == id {
+			return b, nil
+		}
+	}
+	if err := validID(id); err != nil {
+		return nil, err
+	}
+	return nil, errNotFound()
+}
+
+func (s *Server) sortedBoards() []*board {
+	boards := make([]*board, 0, len(s.boards))
+	for _, b := range s.boards {
+		boards = append(boards, b)
+	}
+	sort.Slice(boards, func(i, j int) bool { return boards[i].ID < boards[j].ID })
+	return boards
+}
+
+// boardLists - Lists on a board sorted by position
+func (s *Server) boardLists(b *board) []*list {
+	lists := []*list{}
+	for _, l := range s.lists {
+		if l.IDBoard == b.ID {
+			lists = append(lists, l)
+		}
+	}
+	sort.Slice(lists, func(i, j int) bool { return lists[i].Pos < lists[j].Pos })
+	return lists
+}
+
+// boardLabels - Labels on a board in creation order
+func (s *Server) boardLabels(b *board) []*label {
+	labels := []*label{}
+	for _, l := range s.labels {
+		if l.IDBoard == b.ID {
+			labels = append(labels, l)
+		}
+	}
+	sort.Slice(labels, func(i, j int) bool { return labels[i].ID < labels[j].ID })
+	return labels
+}
+
+// boardCards - Cards on a board sorted by list and position
+func (s *Server) boardCards(b *board) []*card {
+	cards := []*card{}
+	for _, l := range s.boardLists(b) {
+		cards = append(cards, s.listCards(l)...)
+	}
+	return cards
+}
+
+func (s *Server) createBoard(name string, idOrganization *string, defaults bool) *board {
+	me := s.me()
+	b := &board{
+		ID:               s.newID(),
+		Name:             name,
+		IDOrganization:   idOrganization,
+		IDMemberCreator:  me.ID,
+		ShortLink:        shortLink(),
+		DateLastActivity: s.date(),
+		Prefs: boardPrefs{
+			PermissionLevel: "private",
+			Voting:          "disabled",
+			Comments:        "members",
+			Invitations:     "members",
+			CardCovers:      true,
+			CardAging:       "regular",
+			Background:      "blue",
+			BackgroundColor: "#0079BF",
+			CanBePublic:     true,
+			CanBeOrg:        true,
+			CanBePrivate:    true,
+			CanInvite:       true,
+		},
+		LabelNames: map[string]string{},
+	}
+	b.ShortURL = "https://trello.com/b/" + b.ShortLink
+	b.URL = b.ShortURL + "/" + strings.ToLower(strings.ReplaceAll(name, " ", "-"))
+	b.Memberships = []*membership{{ID: s.newID(), IDMember: me.ID, MemberType: "admin"}}
+	s.boards[b.ID] = b
+	me.IDBoards = append(me.IDBoards, b.ID)
+	s.addAction("createBoard", map[string]interface{}{"board": boardRef(b)})
+
+	if defaults {
+		for _, color := range defaultLabelColors {
+			s.createLabel(b, "", color)
+		}
+		for _, name := range defaultLists {
+			s.createList(b, name, "bottom")
+		}
+	}
+	return b
+}
+
+func (s *Server) postBoard(r *http.Request) (interface{}, error) {
+	name := r.Form.Get("name")
+	if name == "" {
+		return nil, errInvalid("value for name")
+	}
+	var idOrganization *string
+	if id := r.Form.Get("idOrganization"); id != "" {
+		if _, ok := s.organizations[id]; !ok {
+			return nil, errInvalid("value for idOrganization")
+		}
+		idOrganization = &id
+	}
+
+	source := r.Form.Get("idBoardSource")
+	if source == "" {
+		defaults, err := parseBool(r.Form.Get("defaultLists"), true)
+		if err != nil {
+			return nil, err
+		}
+		b := s.createBoard(name, idOrganization, defaults)
+		b.Desc = r.Form.Get("desc")
+		return b, nil
+	}
+
+	src, err := s.board(source)
+	if err != nil {
+		return nil, err
+	}
+	if idOrganization == nil {
+		idOrganization = src.IDOrganization
+	}
+	b := s.createBoard(name, idOrganization, false)
+	b.Desc = src.Desc
+	b.Prefs = src.Prefs
+	labelMap := map[string]string{}
+	for _, l := range s.boardLabels(src) {
+		color := ""
+		if l.Color != nil {
+			color = *l.Color
+		}
+		labelMap[l.ID] = s.createLabel(b, l.Name, color).ID
+	}
+	keepCards := strings.Contains(r.Form.Get("keepFromSource"), "cards")
+	for _, l := range s.boardLists(src) {
+		dst := s.createList(b, l.Name, "bottom")
+		if !keepCards {
+			continue
+		}
+		for _, c := range s.listCards(l) {
+			s.copyCard(c, dst, labelMap, "all")
+		}
+	}
+	return b, nil
+}
+
+func (s *Server) routeBoards(r *http.Request, seg []string) (interface{}, error) {
+	if len(seg) == 0 {
+		if r.Method == http.MethodPost {
+			return s.postBoard(r)
+		}
+		return nil, errCannot(r)
+	}
+	b, err := s.board(seg[0])
+	if err != nil {
+		return nil, err
+	}
+	if len(seg) == 1 {
+		switch r.Method {
+		case http.MethodGet:
+			return s.renderNestedBoard(b, r.Form)
+		case http.MethodPut:
+			return s.updateBoard(b, r.Form)
+		case http.MethodDelete:
+			s.deleteBoard(b)
+			return map[string]interface{}{"_value": nil}, nil
+		}
+		return nil, errCannot(r)
+	}
+
+	switch seg[1] {
+	case "lists":
+		return s.boardListsRoute(r, b, seg[2:])
+	case "cards":
+		return s.boardCardsRoute(r, b, seg[2:])
+	case "labels":
+		if len(seg) > 2 {
+			break
+		}
+		switch r.Method {
+		case http.MethodGet:
+			return s.boardLabels(b), nil
+		case http.MethodPost:
+			if r.Form.Get("name") == "" && r.Form.Get("color") == "" {
+				return nil, errInvalid("value for name")
+			}
+			return s.createLabel(b, r.Form.Get("name"), r.Form.Get("color")), nil
+		}
+	case "customFields":
+		if len(seg) == 2 && r.Method == http.MethodGet {
+			return s.boardCustomFields(b), nil
+		}
+	case "checklists":
+		if len(seg) == 2 && r.Method == http.MethodGet {
+			checklists := []*checklist{}
+			for _, c := range s.boardCards(b) {
+				checklists = append(checklists, s.cardChecklists(c)...)
+			}
+			return checklists, nil
+		}
+	case "members":
+		return s.boardMembersRoute(r, b, seg[2:])
+	case "memberships":
+		return s.boardMembershipsRoute(r, b, seg[2:])
+	case "actions":
+		if len(seg) == 2 && r.Method == http.MethodGet {
+			return s.listActions(r, func(a *action) bool { return dataID(a, "board") == b.ID })
+		}
+	default:
+		// PUT /boards/{id}/{field} (including prefs/background)
+		if r.Method == http.MethodPut {
+			form := map[string][]string{strings.Join(seg[1:], "/"): {r.Form.Get("value")}}
+			return s.updateBoard(b, form)
+		}
+	}
+	return nil, errCannot(r)
+}
+
+func (s *Server) updateBoard(b *board, form map[string][]string) (interface{}, error) {
+	old := map[string]interface{}{}
+	for field, values := range form {
+		if len(values) == 0 {
+			continue
+		}
+		value := values[0]
+		switch field {
+		case "name":
+			if value == "" {
+				return nil, errInvalid("value for name")
+			}
+			old["name"], b.Name = b.Name, value
+		case "desc":
+			old["desc"], b.Desc = b.Desc, value
+		case "closed":
+			closed, err := parseBool(value, false)
+			if err != nil {
+				return nil, err
+			}
+			old["closed"], b.Closed = b.Closed, closed
+		case "prefs/background", "prefs_background":
+			old["prefs"], b.Prefs.Background = map[string]interface{}{"background": b.Prefs.Background}, value
+		case "prefs/permissionLevel", "prefs_permissionLevel":
+			old["prefs"], b.Prefs.PermissionLevel = map[string]interface{}{"permissionLevel": b.Prefs.PermissionLevel}, value
+		}
+	}
+	if len(old) > 0 {
+		s.addAction("updateBoard", map[string]interface{}{"board": boardRef(b), "old": old})
+	}
+	return b, nil
+}
+
+func (s *Server) deleteBoard(b *board) {
+	for _, l := range s.boardLists(b) {
+		for _, c := range s.listCards(l) {
+			s.deleteCard(c)
+		}
+		delete(s.lists, l.ID)
+	}
+	for _, l := range s.boardLabels(b) {
+		delete(s.labels, l.ID)
+	}
+	for _, f := range s.boardCustomFields(b) {
+		delete(s.customFields, f.ID)
+	}
+	for _, m := range s.members {
+		m.IDBoards = remove(m.IDBoards, b.ID)
+	}
+	delete(s.boards, b.ID)
+}
+
+func (s *Server) boardListsRoute(r *http.Request, b *board, seg []string) (interface{}, error) {
+	if len(seg) > 0 {
+		return nil, errCannot(r)
+	}
+	switch r.Method {
+	case http.MethodGet:
+		filter := r.Form.Get("filter")
+		lists := []interface{}{}
+		for _, l := range s.boardLists(b) {
+			if filter == "all" || (filter == "closed") == l.Closed {
+				v, err := s.renderNestedList(l, r.Form)
+				if err != nil {
+					return nil, err
+				}
+				lists = append(lists, v)
+			}
+		}
+		return lists, nil
+	case http.MethodPost:
+		if r.Form.Get("name") == "" {
+			return nil, errInvalid("value for name")
+		}
+		return s.createList(b, r.Form.Get("name"), r.Form.Get("pos")), nil
+	}
+	return nil, errCannot(r)
+}
+
+func (s *Server) boardCardsRoute(r *http.Request, b *board, seg []string) (interface{}, error) {
+	if r.Method != http.MethodGet {
+		return nil, errCannot(r)
+	}
+	if len(seg) == 1 {
+		c, err := s.card(seg[0])
+		if err != nil {
+			return nil, err
+		}
+		if c.IDBoard != b.ID {
+			return nil, errNotFound()
+		}
+		return s.renderNestedCard(c, r.Form)
+	}
+	if len(seg) > 1 {
+		return nil, errCannot(r)
+	}
+	filter := r.Form.Get("filter")
+	cards := []*card{}
+	for _, c := range s.boardCards(b) {
+		if filter == "all" || (filter == "closed") == c.Closed {
+			cards = append(cards, c)
+		}
+	}
+	return s.renderNestedCards(cards, r.Form)
+}
+
+// boardMemberResponse - Response for board member changes
+func (s *Server) boardMemberResponse(b *board) interface{} {
+	ids := []string{}
+	for _, ms := range b.Memberships {
+		ids = append(ids, ms.IDMember)
+	}
+	return map[string]interface{}{
+		"id":          b.ID,
+		"members":     s.memberList(ids),
+		"memberships": b.Memberships,
+	}
+}
+
+func (s *Server) boardMembersRoute(r *http.Request, b *board, seg []string) (interface{}, error) {
+	if len(seg) == 0 && r.Method == http.MethodGet {
+		ids := []string{}
+		for _, ms := range b.Memberships {
+			ids = append(ids, ms.IDMember)
+		}
+		return s.memberList(ids), nil
+	}
+	if len(seg) == 0 {
+		return nil, errCannot(r)
+	}
+	m, err := s.member(seg[0])
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case len(seg) == 1 && r.Method == http.MethodPut:
+		memberType := r.Form.Get("type")
+		switch memberType {
+		case "admin", "normal", "observer":
+		default:
+			return nil, errInvalid("value for type")
+		}
+		if ms := b.membership(m.ID); ms != nil {
+			ms.MemberType = memberType
+		} else {
+			b.Memberships = append(b.Memberships, &membership{ID: s.newID(), IDMember: m.ID, MemberType: memberType})
+			m.IDBoards = append(m.IDBoards, b.ID)
+			s.addAction("addMemberToBoard", map[string]interface{}{"board": boardRef(b), "idMemberAdded": m.ID, "member": memberRef(m)})
+		}
+		return s.boardMemberResponse(b), nil
+	case len(seg) == 1 && r.Method == http.MethodDelete:
+		kept := []*membership{}
+		for _, ms := range b.Memberships {
+			if ms.IDMember != m.ID {
+				kept = append(kept, ms)
+			}
+		}
+		b.Memberships = kept
+		m.IDBoards = remove(m.IDBoards, b.ID)
+		return s.boardMemberResponse(b), nil
+	case len(seg) == 2 && seg[1] == "cards" && r.Method == http.MethodGet:
+		cards := []*card{}
+		for _, c := range s.boardCards(b) {
+			if contains(c.IDMembers, m.ID) {
+				cards = append(cards, s.renderCard(c))
+			}
+		}
+		return cards, nil
+	}
+	return nil, errCannot(r)
+}
+
+func (s *Server) boardMembershipsRoute(r *http.Request, b *board, seg []string) (interface{}, error) {
+	if len(seg) == 0 && r.Method == http.MethodGet {
+		return b.Memberships, nil
+	}
+	if len(seg) != 1 {
+		return nil, errCannot(r)
+	}
+	var ms *membership
+	for _, m := range b.Memberships {
+		if m.ID == seg[0] {
+			ms = m
+		}
+	}
+	if ms == nil {
+		if err := validID(seg[0]); err != nil {
+			return nil, err
+		}
+		return nil, errNotFound()
+	}
+	switch r.Method {
+	case http.MethodGet:
+		return ms, nil
+	case http.MethodPut:
+		switch memberType := r.Form.Get("type"); memberType {
+		case "admin", "normal", "observer":
+			ms.MemberType = memberType
+		default:
+			return nil, errInvalid("value for type")
+		}
+		result := *ms
+		if fields := r.Form.Get("member_fields"); fields != "" {
+			result.Member = s.members[ms.IDMember]
+		}
+		return &result, nil
+	}
+	return nil, errCannot(r)
+}
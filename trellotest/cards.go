@@ -0,0 +1,606 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trellotest
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func (s *Server) card(id string) (*card, error) {
+	if c, ok := s.cards[id]; ok {
+		return c, nil
+	}
+	for _, c := range s.cards {
+		if c.ShortLink == id {
+			return c, nil
+		}
+	}
+	if err := validID(id); err != nil {
+		return nil, err
+	}
+	return nil, errNotFound()
+}
+
+// renderCard - Fill in the derived fields (labels, badges) before rendering
+func (s *Server) renderCard(c *card) *card {
+	c.Labels = []*label{}
+	for _, id := range c.IDLabels {
+		if l, ok := s.labels[id]; ok {
+			c.Labels = append(c.Labels, l)
+		}
+	}
+	c.IDChecklists = []string{}
+	c.Badges = badges{Due: c.Due, DueComplete: c.DueComplete, Start: c.Start, Description: c.Desc != ""}
+	c.Badges.Attachments = len(s.cardAttachments(c))
+	for _, cl := range s.cardChecklists(c) {
+		c.IDChecklists = append(c.IDChecklists, cl.ID)
+		for _, item := range cl.CheckItems {
+			c.Badges.CheckItems++
+			if item.State == "complete" {
+				c.Badges.CheckItemsChecked++
+			}
+		}
+	}
+	for _, a := range s.actions {
+		if a.Type == "commentCard" && dataID(a, "card") == c.ID {
+			c.Badges.Comments++
+		}
+	}
+	return c
+}
+
+// parseDate - Normalize a date parameter ("" and "null" clear the date)
+func parseDate(value string) (*string, error) {
+	if value == "" || value == "null" {
+		return nil, nil
+	}
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			date := t.UTC().Format(dateFormat)
+			return &date, nil
+		}
+	}
+	if ms, err := strconv.ParseInt(value, 10, 64); err == nil {
+		date := time.Unix(0, ms*int64(time.Millisecond)).UTC().Format(dateFormat)
+		return &date, nil
+	}
+	return nil, errInvalid("date")
+}
+
+func (s *Server) cardPositions(l *list, except string) []float64 {
+	existing := []float64{}
+	for _, c := range s.listCards(l) {
+		if c.ID != except {
+			existing = append(existing, c.Pos)
+		}
+	}
+	return existing
+}
+
+// newCard - Create an empty card at pos in list l (without recording an action)
+func (s *Server) newCard(l *list, name, pos string) (*card, error) {
+	p, err := nextPos(s.cardPositions(l, ""), pos)
+	if err != nil {
+		return nil, err
+	}
+	b := s.boards[l.IDBoard]
+	b.cardSeq++
+	c := &card{
+		ID:               s.newID(),
+		Name:             name,
+		IDBoard:          b.ID,
+		IDList:           l.ID,
+		IDMembers:        []string{},
+		IDMembersVoted:   []string{},
+		IDLabels:         []string{},
+		Pos:              p,
+		DateLastActivity: s.date(),
+		IDShort:          b.cardSeq,
+		ShortLink:        shortLink(),
+		CheckItemStates:  []interface{}{},
+	}
+	c.ShortURL = "https://trello.com/c/" + c.ShortLink
+	c.URL = c.ShortURL + "/" + strconv.Itoa(c.IDShort) + "-" + strings.ToLower(strings.ReplaceAll(name, " ", "-"))
+	s.cards[c.ID] = c
+	return c, nil
+}
+
+// copyCard - Copy src into list dst, keeping the properties listed in keep
+// ("all" or a comma separated list of attachments, checklists, comments, due,
+// labels, members, start, stickers). Labels are remapped through labelMap.
+func (s *Server) copyCard(src *card, dst *list, labelMap map[string]string, keep string) *card {
+	c, _ := s.newCard(dst, src.Name, "bottom")
+	keeps := func(what string) bool {
+		return keep == "all" || contains(splitIDs(keep), what)
+	}
+	c.Desc = src.Desc
+	c.Closed = src.Closed
+	if keeps("due") {
+		c.Due, c.DueComplete = src.Due, src.DueComplete
+	}
+	if keeps("start") {
+		c.Start = src.Start
+	}
+	if keeps("members") {
+		board := s.boards[dst.IDBoard]
+		for _, id := range src.IDMembers {
+			if board.membership(id) != nil {
+				c.IDMembers = append(c.IDMembers, id)
+			}
+		}
+	}
+	if keeps("labels") {
+		for _, id := range src.IDLabels {
+			if mapped, ok := labelMap[id]; ok {
+				c.IDLabels = append(c.IDLabels, mapped)
+			} else if l, ok := s.labels[id]; ok && l.IDBoard == c.IDBoard {
+				c.IDLabels = append(c.IDLabels, id)
+			}
+		}
+	}
+	if keeps("checklists") {
+		for _, cl := range s.cardChecklists(src) {
+			s.copyChecklist(cl, c, cl.Name, "bottom")
+		}
+	}
+	if keeps("attachments") {
+		for _, a := range s.cardAttachments(src) {
+			s.addAttachment(c, *a)
+		}
+	}
+	if keeps("customFields") {
+		for _, item := range s.cardCustomFieldItems(src) {
+			if f, ok := s.customFields[item.IDCustomField]; ok && f.IDModel == c.IDBoard {
+				copied := *item
+				copied.ID, copied.IDModel = s.newID(), c.ID
+				s.fieldItems[copied.ID] = &copied
+			}
+		}
+	}
+	return c
+}
+
+func (s *Server) deleteCard(c *card) {
+	for _, cl := range s.cardChecklists(c) {
+		delete(s.checklists, cl.ID)
+	}
+	for _, a := range s.cardAttachments(c) {
+		delete(s.attachments, a.ID)
+	}
+	s.deleteCustomFieldItems(func(item *customFieldItem) bool { return item.IDModel == c.ID })
+	delete(s.cards, c.ID)
+}
+
+func (s *Server) postCard(r *http.Request) (interface{}, error) {
+	l, err := s.list(r.Form.Get("idList"))
+	if err != nil {
+		return nil, errInvalid("value for idList")
+	}
+	pos := r.Form.Get("pos")
+	if pos == "" {
+		pos = "bottom"
+	}
+
+	var c *card
+	if source := r.Form.Get("idCardSource"); source != "" {
+		src, err := s.card(source)
+		if err != nil {
+			return nil, errInvalid("value for idCardSource")
+		}
+		keep := r.Form.Get("keepFromSource")
+		if keep == "" {
+			keep = "all"
+		}
+		c = s.copyCard(src, l, nil, keep)
+		if _, err := s.updateCard(c, map[string][]string{"pos": {pos}}, false); err != nil {
+			return nil, err
+		}
+		if name := r.Form.Get("name"); name != "" {
+			c.Name = name
+		}
+		data := s.cardData(c)
+		data["cardSource"] = cardRef(src)
+		s.addAction("copyCard", data)
+	} else {
+		if c, err = s.newCard(l, r.Form.Get("name"), pos); err != nil {
+			return nil, err
+		}
+		s.addAction("createCard", s.cardData(c))
+	}
+
+	form := map[string][]string{}
+	for _, field := range []string{"desc", "due", "start", "dueComplete", "idMembers", "idLabels", "closed", "address", "locationName", "coordinates"} {
+		if value, ok := r.Form[field]; ok && value[0] != "" {
+			form[field] = value
+		}
+	}
+	if _, err := s.updateCard(c, form, false); err != nil {
+		s.deleteCard(c)
+		return nil, err
+	}
+	if r.Form.Get("urlSource") != "" || (r.MultipartForm != nil && len(r.MultipartForm.File["fileSource"]) > 0) {
+		a, err := formAttachment(r, "fileSource", "urlSource")
+		if err != nil {
+			s.deleteCard(c)
+			return nil, err
+		}
+		s.addAttachment(c, a)
+	}
+	return s.renderCard(c), nil
+}
+
+// updateCard - Apply the form fields to the card, recording updateCard actions if record is set
+func (s *Server) updateCard(c *card, form map[string][]string, record bool) (interface{}, error) {
+	old := map[string]interface{}{}
+	var listBefore *list
+	for field, values := range form {
+		if len(values) == 0 {
+			continue
+		}
+		value := values[0]
+		switch field {
+		case "name":
+			if value == "" {
+				return nil, errInvalid("value for name")
+			}
+			old["name"], c.Name = c.Name, value
+		case "desc":
+			old["desc"], c.Desc = c.Desc, value
+		case "closed":
+			closed, err := parseBool(value, false)
+			if err != nil {
+				return nil, err
+			}
+			old["closed"], c.Closed = c.Closed, closed
+		case "due":
+			due, err := parseDate(value)
+			if err != nil {
+				return nil, err
+			}
+			old["due"], c.Due = c.Due, due
+		case "start":
+			start, err := parseDate(value)
+			if err != nil {
+				return nil, err
+			}
+			old["start"], c.Start = c.Start, start
+		case "idAttachmentCover":
+			var cover *string
+			if value != "" && value != "null" {
+				a, err := s.cardAttachment(c, value)
+				if err != nil || !a.IsUpload || !strings.HasPrefix(a.MimeType, "image/") {
+					return nil, errInvalid("value for idAttachmentCover")
+				}
+				cover = &a.ID
+			}
+			old["idAttachmentCover"], c.IDAttachmentCover = c.IDAttachmentCover, cover
+		case "address":
+			old["address"], c.Address = c.Address, optionalString(value)
+		case "locationName":
+			old["locationName"], c.LocationName = c.LocationName, optionalString(value)
+		case "coordinates":
+			coords, err := parseCoordinates(value)
+			if err != nil {
+				return nil, err
+			}
+			old["coordinates"], c.Coordinates = c.Coordinates, coords
+		case "dueComplete":
+			complete, err := parseBool(value, false)
+			if err != nil {
+				return nil, err
+			}
+			old["dueComplete"], c.DueComplete = c.DueComplete, complete
+		case "idMembers":
+			ids := splitIDs(value)
+			for _, id := range ids {
+				if _, ok := s.members[id]; !ok {
+					return nil, errInvalid("value for idMembers")
+				}
+			}
+			old["idMembers"], c.IDMembers = c.IDMembers, ids
+		case "idLabels":
+			ids := splitIDs(value)
+			for _, id := range ids {
+				if _, ok := s.labels[id]; !ok {
+					return nil, errInvalid("value for idLabels")
+				}
+			}
+			old["idLabels"], c.IDLabels = c.IDLabels, ids
+		}
+	}
+	// Moves are applied last so pos is computed relative to the destination list
+	boardBefore := c.IDBoard
+	if values, ok := form["idBoard"]; ok && len(values) > 0 && values[0] != c.IDBoard {
+		b, err := s.board(values[0])
+		if err != nil {
+			return nil, errInvalid("value for idBoard")
+		}
+		if lists, ok := form["idList"]; ok && len(lists) > 0 {
+			if l, err := s.list(lists[0]); err != nil || l.IDBoard != b.ID {
+				return nil, errInvalid("value for idList")
+			}
+		} else {
+			lists := s.boardLists(b)
+			if len(lists) == 0 {
+				return nil, errInvalid("value for idList")
+			}
+			form["idList"] = []string{lists[0].ID}
+		}
+		old["idBoard"] = c.IDBoard
+	}
+	if values, ok := form["idList"]; ok && len(values) > 0 && values[0] != c.IDList {
+		l, err := s.list(values[0])
+		if err != nil {
+			return nil, errInvalid("value for idList")
+		}
+		listBefore = s.lists[c.IDList]
+		old["idList"], c.IDList = c.IDList, l.ID
+		c.IDBoard = l.IDBoard
+		if _, ok := form["pos"]; !ok {
+			form["pos"] = []string{"bottom"}
+		}
+	}
+	if c.IDBoard != boardBefore {
+		// Labels, members and custom field values of the previous board do not follow the card
+		b := s.boards[c.IDBoard]
+		labels, members := []string{}, []string{}
+		for _, id := range c.IDLabels {
+			if l, ok := s.labels[id]; ok && l.IDBoard == b.ID {
+				labels = append(labels, id)
+			}
+		}
+		for _, id := range c.IDMembers {
+			if b.membership(id) != nil {
+				members = append(members, id)
+			}
+		}
+		c.IDLabels, c.IDMembers = labels, members
+		s.deleteCustomFieldItems(func(item *customFieldItem) bool {
+			f, ok := s.customFields[item.IDCustomField]
+			return item.IDModel == c.ID && (!ok || f.IDModel != b.ID)
+		})
+	}
+	if values, ok := form["pos"]; ok && len(values) > 0 {
+		pos, err := nextPos(s.cardPositions(s.lists[c.IDList], c.ID), values[0])
+		if err != nil {
+			return nil, err
+		}
+		old["pos"], c.Pos = c.Pos, pos
+	}
+	if len(old) == 0 {
+		return s.renderCard(c), nil
+	}
+	c.DateLastActivity = s.date()
+	if record {
+		data := s.cardData(c)
+		data["old"] = old
+		// The card reference also carries the new value of each changed field
+		changed := make([]string, 0, len(old))
+		for field := range old {
+			changed = append(changed, field)
+		}
+		if values, err := selectFields(c, strings.Join(changed, ",")); err == nil {
+			ref := data["card"].(map[string]interface{})
+			for field, value := range values.(map[string]interface{}) {
+				ref[field] = value
+			}
+		}
+		if listBefore != nil {
+			data["listBefore"] = listRef(listBefore)
+			data["listAfter"] = listRef(s.lists[c.IDList])
+		}
+		s.addAction("updateCard", data)
+		if c.IDBoard != boardBefore {
+			from, to := s.cardData(c), s.cardData(c)
+			from["board"], from["boardTarget"] = boardRef(s.boards[boardBefore]), boardRef(s.boards[c.IDBoard])
+			to["boardSource"] = boardRef(s.boards[boardBefore])
+			s.addAction("moveCardFromBoard", from)
+			s.addAction("moveCardToBoard", to)
+		}
+	}
+	return s.renderCard(c), nil
+}
+
+func (s *Server) routeCards(r *http.Request, seg []string) (interface{}, error) {
+	if len(seg) == 0 {
+		if r.Method == http.MethodPost {
+			return s.postCard(r)
+		}
+		return nil, errCannot(r)
+	}
+	c, err := s.card(seg[0])
+	if err != nil {
+		return nil, err
+	}
+	if len(seg) == 1 {
+		switch r.Method {
+		case http.MethodGet:
+			return s.renderNestedCard(c, r.Form)
+		case http.MethodPut:
+			// Trello rejects the whole update if any field is invalid
+			before := *c
+			updated, err := s.updateCard(c, r.Form, true)
+			if err != nil {
+				*c = before
+			}
+			return updated, err
+		case http.MethodDelete:
+			data := map[string]interface{}{"card": map[string]interface{}{"id": c.ID, "idShort": c.IDShort}}
+			data["board"] = boardRef(s.boards[c.IDBoard])
+			data["list"] = listRef(s.lists[c.IDList])
+			s.addAction("deleteCard", data)
+			s.deleteCard(c)
+			return limits(), nil
+		}
+		return nil, errCannot(r)
+	}
+
+	switch seg[1] {
+	case "members":
+		if len(seg) == 2 && r.Method == http.MethodGet {
+			return s.memberList(c.IDMembers), nil
+		}
+	case "idMembers":
+		return s.cardMembersRoute(r, c, seg[2:])
+	case "idLabels":
+		return s.cardLabelsRoute(r, c, seg[2:])
+	case "labels":
+		if len(seg) == 2 && r.Method == http.MethodPost {
+			l := s.createLabel(s.boards[c.IDBoard], r.Form.Get("name"), r.Form.Get("color"))
+			c.IDLabels = append(c.IDLabels, l.ID)
+			return l, nil
+		}
+	case "checklists":
+		if len(seg) == 2 && r.Method == http.MethodGet {
+			return s.cardChecklists(c), nil
+		}
+		if len(seg) == 2 && r.Method == http.MethodPost {
+			return s.postCardChecklist(r, c)
+		}
+	case "attachments":
+		return s.cardAttachmentsRoute(r, c, seg[2:])
+	case "checklist":
+		// POST /cards/{id}/checklist/{idChecklist}/checkItem/{idCheckItem}/convertToCard
+		if len(seg) == 6 && seg[3] == "checkItem" && seg[5] == "convertToCard" && r.Method == http.MethodPost {
+			return s.convertCheckItem(c, seg[2], seg[4])
+		}
+	case "checkItem":
+		if len(seg) == 3 && r.Method == http.MethodPut {
+			cl, item, err := s.cardCheckItem(c, seg[2])
+			if err != nil {
+				return nil, err
+			}
+			return s.updateCheckItem(c, cl, item, r.Form)
+		}
+	case "customFieldItems":
+		if len(seg) == 2 && r.Method == http.MethodGet {
+			return s.cardCustomFieldItems(c), nil
+		}
+	case "customField":
+		if len(seg) == 4 && seg[3] == "item" && r.Method == http.MethodPut {
+			f, err := s.customField(seg[2])
+			if err != nil {
+				return nil, err
+			}
+			return s.putCustomFieldItem(r, c, f)
+		}
+	case "actions":
+		if len(seg) == 2 && r.Method == http.MethodGet {
+			return s.listActions(r, func(a *action) bool { return dataID(a, "card") == c.ID })
+		}
+		if len(seg) == 3 && seg[2] == "comments" && r.Method == http.MethodPost {
+			if r.Form.Get("text") == "" {
+				return nil, errInvalid("value for text")
+			}
+			data := s.cardData(c)
+			data["text"] = r.Form.Get("text")
+			return s.addAction("commentCard", data), nil
+		}
+	default:
+		// PUT /cards/{id}/{field}
+		if len(seg) == 2 && r.Method == http.MethodPut {
+			return s.updateCard(c, map[string][]string{seg[1]: {r.Form.Get("value")}}, true)
+		}
+	}
+	return nil, errCannot(r)
+}
+
+func (s *Server) cardMembersRoute(r *http.Request, c *card, seg []string) (interface{}, error) {
+	switch {
+	case len(seg) == 0 && r.Method == http.MethodPost:
+		m, err := s.member(r.Form.Get("value"))
+		if err != nil {
+			return nil, errInvalid("value for value")
+		}
+		if contains(c.IDMembers, m.ID) {
+			return nil, errInvalid("member is already on the card")
+		}
+		c.IDMembers = append(c.IDMembers, m.ID)
+		data := s.cardData(c)
+		data["idMember"] = m.ID
+		data["member"] = memberRef(m)
+		s.addAction("addMemberToCard", data)
+		return s.memberList(c.IDMembers), nil
+	case len(seg) == 1 && r.Method == http.MethodDelete:
+		m, err := s.member(seg[0])
+		if err != nil {
+			return nil, err
+		}
+		c.IDMembers = remove(c.IDMembers, m.ID)
+		data := s.cardData(c)
+		data["idMember"] = m.ID
+		data["member"] = memberRef(m)
+		s.addAction("removeMemberFromCard", data)
+		return s.memberList(c.IDMembers), nil
+	}
+	return nil, errCannot(r)
+}
+
+func (s *Server) cardLabelsRoute(r *http.Request, c *card, seg []string) (interface{}, error) {
+	switch {
+	case len(seg) == 0 && r.Method == http.MethodPost:
+		l, ok := s.labels[r.Form.Get("value")]
+		if !ok || l.IDBoard != c.IDBoard {
+			return nil, errInvalid("value for value")
+		}
+		if contains(c.IDLabels, l.ID) {
+			return nil, errInvalid("that label is already on the card")
+		}
+		c.IDLabels = append(c.IDLabels, l.ID)
+		data := s.cardData(c)
+		data["label"] = map[string]interface{}{"id": l.ID, "name": l.Name, "color": l.Color}
+		s.addAction("addLabelToCard", data)
+		return c.IDLabels, nil
+	case len(seg) == 1 && r.Method == http.MethodDelete:
+		if !contains(c.IDLabels, seg[0]) {
+			return nil, errNotFound()
+		}
+		c.IDLabels = remove(c.IDLabels, seg[0])
+		return []interface{}{}, nil
+	}
+	return nil, errCannot(r)
+}
+
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// parseCoordinates - Parse "latitude,longitude" (empty removes the coordinates)
+func parseCoordinates(value string) (*coordinates, error) {
+	if value == "" {
+		return nil, nil
+	}
+	parts := strings.Split(value, ",")
+	if len(parts) != 2 {
+		return nil, errInvalid("value for coordinates")
+	}
+	lat, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	if err != nil || lat < -90 || lat > 90 {
+		return nil, errInvalid("value for coordinates")
+	}
+	long, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	if err != nil || long < -180 || long > 180 {
+		return nil, errInvalid("value for coordinates")
+	}
+	return &coordinates{Latitude: lat, Longitude: long}, nil
+}
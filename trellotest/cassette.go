@@ -0,0 +1,291 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trellotest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Mode - Recorder mode
+type Mode int
+
+// Recorder modes
+const (
+	// ReplayMode serves responses from the cassette file and never touches the network
+	ReplayMode Mode = iota
+	// RecordMode forwards requests to the real transport and records the exchanges
+	RecordMode
+)
+
+// redacted replaces credentials in recorded exchanges
+const redacted = "REDACTED"
+
+// Cassette - Recorded HTTP exchanges (the fixture file format)
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction - A single recorded request and response
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+	used     bool
+}
+
+// RecordedRequest - Request fields used for matching (credentials removed)
+type RecordedRequest struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Query  string `json:"query,omitempty"`
+	Form   string `json:"form,omitempty"`
+}
+
+// RecordedResponse - Response replayed for a matching request
+type RecordedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body"`
+	// Encoding is "base64" for bodies that are not UTF-8 text (downloaded files)
+	Encoding string `json:"encoding,omitempty"`
+}
+
+// Recorder - http.RoundTripper that records exchanges to, or replays them from, a cassette file
+//
+// Requests are matched by method, path, and the normalized query and form payload
+// (sorted, with the key and token removed; files of multipart bodies by name and hash). Each recorded interaction is replayed
+// once, in order. Use it as the transport of the http.Client given to the trello client:
+//
+//	rec, err := trellotest.NewRecorder("testdata/board.json", trellotest.ReplayMode, nil)
+//	client, err := trello.NewClient(trello.WithHTTPClient(&http.Client{Transport: rec}), trello.WithAuth(key, &token))
+type Recorder struct {
+	Mode Mode
+	Path string
+
+	transport http.RoundTripper
+	mu        sync.Mutex
+	cassette  *Cassette
+	secrets   map[string]bool
+}
+
+// NewRecorder - Create a Recorder for the cassette at path. In ReplayMode the cassette
+// is loaded immediately; in RecordMode requests are sent with transport
+// (http.DefaultTransport if nil) and the cassette is written by Save.
+func NewRecorder(path string, mode Mode, transport http.RoundTripper) (*Recorder, error) {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	r := &Recorder{
+		Mode:      mode,
+		Path:      path,
+		transport: transport,
+		cassette:  &Cassette{},
+		secrets:   map[string]bool{},
+	}
+	if mode == ReplayMode {
+		body, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal(body, r.cassette); err != nil {
+			return nil, fmt.Errorf("Unable to parse cassette %q: %v", path, err)
+		}
+	}
+	return r, nil
+}
+
+// RoundTrip - Record or replay a single exchange
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := req.Context().Err(); err != nil {
+		return nil, err
+	}
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = ioutil.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// Credentials may also appear in paths (/tokens/{token}/webhooks) and bodies
+	query := req.URL.Query()
+	for _, name := range []string{"key", "token"} {
+		if value := query.Get(name); value != "" {
+			r.secrets[value] = true
+		}
+	}
+	if r.Mode == RecordMode {
+		return r.record(req, body)
+	}
+	return r.replay(req, body)
+}
+
+func (r *Recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	out := req.Clone(req.Context())
+	if body != nil {
+		out.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+	resp, err := r.transport.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	header := resp.Header.Clone()
+	header.Del("Set-Cookie")
+	recorded := RecordedResponse{StatusCode: resp.StatusCode, Header: header}
+	if utf8.Valid(respBody) {
+		recorded.Body = r.scrub(string(respBody))
+	} else {
+		recorded.Body, recorded.Encoding = base64.StdEncoding.EncodeToString(respBody), "base64"
+	}
+	r.cassette.Interactions = append(r.cassette.Interactions, &Interaction{
+		Request:  r.recordedRequest(req, body),
+		Response: recorded,
+	})
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request, body []byte) (*http.Response, error) {
+	want := r.recordedRequest(req, body)
+	for _, i := range r.cassette.Interactions {
+		if i.used || i.Request != want {
+			continue
+		}
+		respBody := []byte(i.Response.Body)
+		if i.Response.Encoding == "base64" {
+			decoded, err := base64.StdEncoding.DecodeString(i.Response.Body)
+			if err != nil {
+				return nil, fmt.Errorf("trellotest: invalid base64 body for %s %s in %q", want.Method, want.Path, r.Path)
+			}
+			respBody = decoded
+		}
+		i.used = true
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", i.Response.StatusCode, http.StatusText(i.Response.StatusCode)),
+			StatusCode:    i.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        i.Response.Header.Clone(),
+			Body:          ioutil.NopCloser(bytes.NewReader(respBody)),
+			ContentLength: int64(len(respBody)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("trellotest: no recorded interaction for %s %s in %q", want.Method, want.Path, r.Path)
+}
+
+// Save - Write the recorded exchanges to the cassette file (RecordMode only)
+func (r *Recorder) Save() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.Mode != RecordMode {
+		return nil
+	}
+	body, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(r.Path, append(body, '\n'), os.FileMode(0644))
+}
+
+// recordedRequest - Normalize a request for matching (and storage)
+func (r *Recorder) recordedRequest(req *http.Request, body []byte) RecordedRequest {
+	rec := RecordedRequest{
+		Method: req.Method,
+		Path:   r.scrub(req.URL.Path),
+		Query:  r.scrub(normalize(req.URL.Query())),
+	}
+	if len(body) > 0 {
+		if form, err := multipartForm(req.Header.Get("Content-Type"), body); err == nil {
+			rec.Form = normalize(form)
+		} else if form, err := url.ParseQuery(string(body)); err == nil {
+			rec.Form = normalize(form)
+		} else {
+			rec.Form = string(body)
+		}
+	}
+	rec.Form = r.scrub(rec.Form)
+	return rec
+}
+
+// multipartForm - Fields of a multipart body, independent of its random boundary
+// A file is matched by its name and the SHA-256 of its content.
+func multipartForm(contentType string, body []byte) (url.Values, error) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil || !strings.HasPrefix(mediaType, "multipart/") {
+		return nil, fmt.Errorf("not a multipart body")
+	}
+	form := url.Values{}
+	reader := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return form, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		content, err := ioutil.ReadAll(part)
+		if err != nil {
+			return nil, err
+		}
+		value := string(content)
+		if part.FileName() != "" {
+			value = fmt.Sprintf("%s sha256:%x", part.FileName(), sha256.Sum256(content))
+		}
+		form.Add(part.FormName(), value)
+	}
+}
+
+// scrub - Replace any credential seen in a request query
+func (r *Recorder) scrub(s string) string {
+	for secret := range r.secrets {
+		s = strings.ReplaceAll(s, secret, redacted)
+		s = strings.ReplaceAll(s, url.QueryEscape(secret), redacted)
+	}
+	return s
+}
+
+// normalize - Sorted encoding of values without the credentials
+func normalize(values url.Values) string {
+	values.Del("key")
+	values.Del("token")
+	return values.Encode()
+}
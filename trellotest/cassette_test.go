@@ -0,0 +1,125 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trellotest_test
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	trello "github.com/TJM/go-trello"
+	"github.com/TJM/go-trello/trellotest"
+	goblin "github.com/franela/goblin"
+	. "github.com/onsi/gomega"
+)
+
+func TestRecorder(t *testing.T) {
+	g := goblin.Goblin(t)
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+
+	g.Describe("Cassette recorder tests", func() {
+		var dir, path string
+		var boardID string
+		const key, secretToken = "cassette-key", "cassette-token"
+		// Not valid UTF-8, like most uploaded files
+		content := []byte{0x89, 'P', 'N', 'G', 0xff, 0x00, 0xfe}
+
+		// attach - Upload content to a new card of the board and download it back
+		attach := func(client *trello.Client, board *trello.Board) []byte {
+			lists, err := board.Lists()
+			Expect(err).To(BeNil())
+			card, err := lists[0].AddCard(trello.Card{Name: "Attached"})
+			Expect(err).To(BeNil())
+			attachment, err := card.AttachFile("content.bin", bytes.NewReader(content), "application/octet-stream")
+			Expect(err).To(BeNil())
+			var buf bytes.Buffer
+			_, err = attachment.Download(context.Background(), &buf)
+			Expect(err).To(BeNil())
+			return buf.Bytes()
+		}
+
+		newClient := func(endpoint string, rec *trellotest.Recorder) *trello.Client {
+			token := secretToken
+			client, err := trello.NewClient(
+				trello.WithBaseURL(endpoint),
+				trello.WithHTTPClient(&http.Client{Transport: rec}),
+				trello.WithAuth(key, &token),
+			)
+			Expect(err).To(BeNil())
+			return client
+		}
+
+		g.Before(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "trellotest")
+			Expect(err).To(BeNil())
+			path = filepath.Join(dir, "cassette.json")
+		})
+
+		g.After(func() {
+			os.RemoveAll(dir)
+		})
+
+		g.It("should record exchanges without credentials", func() {
+			server := trellotest.NewServer()
+			server.Key, server.Token = key, secretToken
+			defer server.Close()
+			rec, err := trellotest.NewRecorder(path, trellotest.RecordMode, nil)
+			Expect(err).To(BeNil())
+			client := newClient(server.Endpoint(), rec)
+
+			board, err := client.CreateBoard("Recorded Board")
+			Expect(err).To(BeNil())
+			boardID = board.ID
+			_, err = client.Board(boardID)
+			Expect(err).To(BeNil())
+			Expect(attach(client, board)).To(Equal(content))
+			Expect(rec.Save()).To(BeNil())
+
+			body, err := ioutil.ReadFile(path)
+			Expect(err).To(BeNil())
+			Expect(string(body)).To(ContainSubstring("Recorded Board"))
+			Expect(strings.Contains(string(body), key)).To(BeFalse())
+			Expect(strings.Contains(string(body), secretToken)).To(BeFalse())
+		})
+
+		g.It("should replay exchanges without a server", func() {
+			rec, err := trellotest.NewRecorder(path, trellotest.ReplayMode, nil)
+			Expect(err).To(BeNil())
+			client := newClient("http://127.0.0.1:0/1", rec)
+
+			board, err := client.CreateBoard("Recorded Board")
+			Expect(err).To(BeNil())
+			Expect(board.ID).To(Equal(boardID))
+			board, err = client.Board(boardID)
+			Expect(err).To(BeNil())
+			Expect(board.Name).To(Equal("Recorded Board"))
+			// Multipart bodies match whatever their boundary, and binary bodies are replayed intact
+			Expect(attach(client, board)).To(Equal(content))
+
+			// Each interaction is replayed only once
+			_, err = client.Board(boardID)
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(ContainSubstring("no recorded interaction"))
+		})
+	})
+}
@@ -0,0 +1,326 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trellotest
+
+import (
+	"net/http"
+	"sort"
+)
+
+func (s *Server) checklist(id string) (*checklist, error) {
+	if err := validID(id); err != nil {
+		return nil, err
+	}
+	if cl, ok := s.checklists[id]; ok {
+		return cl, nil
+	}
+	return nil, errNotFound()
+}
+
+// cardChecklists - Checklists on a card sorted by position
+func (s *Server) cardChecklists(c *card) []*checklist {
+	checklists := []*checklist{}
+	for _, cl := range s.checklists {
+		if cl.IDCard == c.ID {
+			checklists = append(checklists, cl)
+		}
+	}
+	sort.Slice(checklists, func(i, j int) bool { return checklists[i].Pos < checklists[j].Pos })
+	return checklists
+}
+
+func (s *Server) createChecklist(c *card, name, pos string) (*checklist, error) {
+	existing := []float64{}
+	for _, cl := range s.cardChecklists(c) {
+		existing = append(existing, cl.Pos)
+	}
+	p, err := nextPos(existing, pos)
+	if err != nil {
+		return nil, err
+	}
+	cl := &checklist{
+		ID:         s.newID(),
+		Name:       name,
+		IDBoard:    c.IDBoard,
+		IDCard:     c.ID,
+		Pos:        p,
+		CheckItems: []*checkItem{},
+	}
+	s.checklists[cl.ID] = cl
+	data := s.cardData(c)
+	data["checklist"] = map[string]interface{}{"id": cl.ID, "name": cl.Name}
+	s.addAction("addChecklistToCard", data)
+	return cl, nil
+}
+
+// copyChecklist - Copy src (and its items, unchecked state kept) onto card c
+func (s *Server) copyChecklist(src *checklist, c *card, name, pos string) (*checklist, error) {
+	if name == "" {
+		name = src.Name
+	}
+	cl, err := s.createChecklist(c, name, pos)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range src.CheckItems {
+		copied := *item
+		copied.ID = s.newID()
+		copied.IDChecklist = cl.ID
+		cl.CheckItems = append(cl.CheckItems, &copied)
+	}
+	return cl, nil
+}
+
+func (s *Server) postCardChecklist(r *http.Request, c *card) (interface{}, error) {
+	if source := r.Form.Get("idChecklistSource"); source != "" {
+		src, err := s.checklist(source)
+		if err != nil {
+			return nil, errInvalid("value for idChecklistSource")
+		}
+		return s.copyChecklist(src, c, r.Form.Get("name"), r.Form.Get("pos"))
+	}
+	return s.createChecklist(c, r.Form.Get("name"), r.Form.Get("pos"))
+}
+
+func (s *Server) addCheckItem(cl *checklist, name, pos string, checked bool) (*checkItem, error) {
+	if name == "" {
+		return nil, errInvalid("value for name")
+	}
+	existing := []float64{}
+	for _, item := range cl.CheckItems {
+		existing = append(existing, item.Pos)
+	}
+	p, err := nextPos(existing, pos)
+	if err != nil {
+		return nil, err
+	}
+	item := &checkItem{ID: s.newID(), Name: name, Pos: p, State: "incomplete", IDChecklist: cl.ID}
+	if checked {
+		item.State = "complete"
+	}
+	cl.CheckItems = append(cl.CheckItems, item)
+	sortCheckItems(cl)
+	return item, nil
+}
+
+func sortCheckItems(cl *checklist) {
+	sort.SliceStable(cl.CheckItems, func(i, j int) bool { return cl.CheckItems[i].Pos < cl.CheckItems[j].Pos })
+}
+
+func (s *Server) routeChecklists(r *http.Request, seg []string) (interface{}, error) {
+	if len(seg) == 0 {
+		if r.Method != http.MethodPost {
+			return nil, errCannot(r)
+		}
+		c, err := s.card(r.Form.Get("idCard"))
+		if err != nil {
+			return nil, errInvalid("value for idCard")
+		}
+		return s.postCardChecklist(r, c)
+	}
+	cl, err := s.checklist(seg[0])
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case len(seg) == 1 && r.Method == http.MethodGet:
+		return cl, nil
+	case len(seg) == 1 && r.Method == http.MethodPut:
+		return s.updateChecklist(cl, r.Form)
+	case len(seg) == 1 && r.Method == http.MethodDelete:
+		delete(s.checklists, cl.ID)
+		return limits(), nil
+	case len(seg) == 2 && seg[1] == "checkItems" && r.Method == http.MethodGet:
+		return cl.CheckItems, nil
+	case len(seg) == 2 && seg[1] == "checkItems" && r.Method == http.MethodPost:
+		checked, err := parseBool(r.Form.Get("checked"), false)
+		if err != nil {
+			return nil, err
+		}
+		return s.addCheckItem(cl, r.Form.Get("name"), r.Form.Get("pos"), checked)
+	case len(seg) == 2 && r.Method == http.MethodPut:
+		return s.updateChecklist(cl, map[string][]string{seg[1]: {r.Form.Get("value")}})
+	case len(seg) == 3 && seg[1] == "checkItems":
+		for i, item := range cl.CheckItems {
+			if item.ID != seg[2] {
+				continue
+			}
+			switch r.Method {
+			case http.MethodGet:
+				return item, nil
+			case http.MethodDelete:
+				cl.CheckItems = append(cl.CheckItems[:i], cl.CheckItems[i+1:]...)
+				return limits(), nil
+			}
+			return nil, errCannot(r)
+		}
+		if err := validID(seg[2]); err != nil {
+			return nil, err
+		}
+		return nil, errNotFound()
+	}
+	return nil, errCannot(r)
+}
+
+func (s *Server) updateChecklist(cl *checklist, form map[string][]string) (interface{}, error) {
+	for field, values := range form {
+		if len(values) == 0 {
+			continue
+		}
+		value := values[0]
+		switch field {
+		case "name":
+			if value == "" {
+				return nil, errInvalid("value for name")
+			}
+			cl.Name = value
+		case "pos":
+			existing := []float64{}
+			for _, other := range s.checklists {
+				if other.IDCard == cl.IDCard && other.ID != cl.ID {
+					existing = append(existing, other.Pos)
+				}
+			}
+			pos, err := nextPos(existing, value)
+			if err != nil {
+				return nil, err
+			}
+			cl.Pos = pos
+		}
+	}
+	return cl, nil
+}
+
+// cardCheckItem - Item of a checklist on card c, with its checklist
+func (s *Server) cardCheckItem(c *card, id string) (*checklist, *checkItem, error) {
+	if err := validID(id); err != nil {
+		return nil, nil, err
+	}
+	for _, cl := range s.cardChecklists(c) {
+		for _, item := range cl.CheckItems {
+			if item.ID == id {
+				return cl, item, nil
+			}
+		}
+	}
+	return nil, nil, errNotFound()
+}
+
+// updateCheckItem - Apply the form fields to an item of a checklist on card c
+func (s *Server) updateCheckItem(c *card, cl *checklist, item *checkItem, form map[string][]string) (interface{}, error) {
+	updated := *item
+	old := map[string]interface{}{}
+	for field, values := range form {
+		if len(values) == 0 {
+			continue
+		}
+		value := values[0]
+		switch field {
+		case "name":
+			if value == "" {
+				return nil, errInvalid("value for name")
+			}
+			old["name"], updated.Name = item.Name, value
+		case "state":
+			switch value {
+			case "complete", "true":
+				updated.State = "complete"
+			case "incomplete", "false":
+				updated.State = "incomplete"
+			default:
+				return nil, errInvalid("value for state")
+			}
+		case "due":
+			due, err := parseDate(value)
+			if err != nil {
+				return nil, err
+			}
+			old["due"], updated.Due = item.Due, due
+		case "idMember":
+			if value == "" || value == "null" {
+				old["idMember"], updated.IDMember = item.IDMember, nil
+				continue
+			}
+			if s.boards[c.IDBoard].membership(value) == nil {
+				return nil, errInvalid("value for idMember")
+			}
+			old["idMember"], updated.IDMember = item.IDMember, &value
+		}
+	}
+	if values, ok := form["pos"]; ok && len(values) > 0 {
+		existing := []float64{}
+		for _, other := range cl.CheckItems {
+			if other.ID != item.ID {
+				existing = append(existing, other.Pos)
+			}
+		}
+		pos, err := nextPos(existing, values[0])
+		if err != nil {
+			return nil, err
+		}
+		updated.Pos = pos
+	}
+	stateChanged := updated.State != item.State
+	*item = updated
+	sortCheckItems(cl)
+
+	data := func() map[string]interface{} {
+		data := s.cardData(c)
+		data["checklist"] = map[string]interface{}{"id": cl.ID, "name": cl.Name}
+		data["checkItem"] = map[string]interface{}{"id": item.ID, "name": item.Name, "state": item.State}
+		return data
+	}
+	if stateChanged {
+		s.addAction("updateCheckItemStateOnCard", data())
+	}
+	if len(old) > 0 {
+		updateData := data()
+		updateData["old"] = old
+		s.addAction("updateCheckItem", updateData)
+	}
+	return item, nil
+}
+
+// convertCheckItem - Replace an item of a checklist on card c by a card in the list of c
+func (s *Server) convertCheckItem(c *card, checklistID, itemID string) (interface{}, error) {
+	cl, item, err := s.cardCheckItem(c, itemID)
+	if err != nil {
+		return nil, err
+	}
+	if cl.ID != checklistID {
+		return nil, errNotFound()
+	}
+	converted, err := s.newCard(s.lists[c.IDList], item.Name, "bottom")
+	if err != nil {
+		return nil, err
+	}
+	converted.Due = item.Due
+	if item.IDMember != nil {
+		converted.IDMembers = []string{*item.IDMember}
+	}
+	for i, other := range cl.CheckItems {
+		if other.ID == item.ID {
+			cl.CheckItems = append(cl.CheckItems[:i], cl.CheckItems[i+1:]...)
+			break
+		}
+	}
+	data := s.cardData(converted)
+	data["cardSource"] = cardRef(c)
+	data["checklist"] = map[string]interface{}{"id": cl.ID, "name": cl.Name}
+	s.addAction("convertToCardFromCheckItem", data)
+	return s.renderCard(converted), nil
+}
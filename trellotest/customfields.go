@@ -0,0 +1,373 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trellotest
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var customFieldTypes = []string{"text", "number", "date", "checkbox", "list"}
+
+// optionInput - Option of a dropdown field, as sent when creating it
+type optionInput struct {
+	Value map[string]string `json:"value"`
+	Color string            `json:"color"`
+	Pos   json.RawMessage   `json:"pos"`
+}
+
+// decodeJSON - Decode the JSON body of a request (parameters nested in objects are sent as JSON)
+func decodeJSON(r *http.Request, v interface{}) error {
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		return errInvalid("content type, expected application/json")
+	}
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return errInvalid("JSON body")
+	}
+	return nil
+}
+
+// jsonPos - A pos sent as a JSON number or string ("top", "bottom"), "bottom" if absent
+func jsonPos(raw json.RawMessage) string {
+	var pos string
+	if len(raw) == 0 {
+		return "bottom"
+	}
+	if err := json.Unmarshal(raw, &pos); err == nil {
+		return pos
+	}
+	return string(raw)
+}
+
+func (s *Server) customField(id string) (*customField, error) {
+	if err := validID(id); err != nil {
+		return nil, err
+	}
+	if f, ok := s.customFields[id]; ok {
+		return f, nil
+	}
+	return nil, errNotFound()
+}
+
+func (s *Server) boardCustomFields(b *board) []*customField {
+	fields := []*customField{}
+	for _, f := range s.customFields {
+		if f.IDModel == b.ID {
+			fields = append(fields, f)
+		}
+	}
+	sort.Slice(fields, func(i, j int) bool { return fields[i].Pos < fields[j].Pos })
+	return fields
+}
+
+func (s *Server) cardCustomFieldItems(c *card) []*customFieldItem {
+	items := []*customFieldItem{}
+	for _, item := range s.fieldItems {
+		if item.IDModel == c.ID {
+			items = append(items, item)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].ID < items[j].ID })
+	return items
+}
+
+func (s *Server) cardCustomFieldItem(c *card, f *customField) *customFieldItem {
+	for _, item := range s.fieldItems {
+		if item.IDModel == c.ID && item.IDCustomField == f.ID {
+			return item
+		}
+	}
+	return nil
+}
+
+func (s *Server) deleteCustomFieldItems(match func(*customFieldItem) bool) {
+	for id, item := range s.fieldItems {
+		if match(item) {
+			delete(s.fieldItems, id)
+		}
+	}
+}
+
+func (s *Server) addOption(f *customField, in optionInput) (*customFieldOption, error) {
+	if in.Value["text"] == "" {
+		return nil, errInvalid("value for option text")
+	}
+	positions := []float64{}
+	for _, o := range f.Options {
+		positions = append(positions, o.Pos)
+	}
+	pos, err := nextPos(positions, jsonPos(in.Pos))
+	if err != nil {
+		return nil, err
+	}
+	color := in.Color
+	if color == "" {
+		color = "none"
+	}
+	o := &customFieldOption{ID: s.newID(), IDCustomField: f.ID, Value: map[string]string{"text": in.Value["text"]}, Color: color, Pos: pos}
+	f.Options = append(f.Options, o)
+	sort.Slice(f.Options, func(i, j int) bool { return f.Options[i].Pos < f.Options[j].Pos })
+	return o, nil
+}
+
+func (s *Server) postCustomField(r *http.Request) (interface{}, error) {
+	var in struct {
+		IDModel   string          `json:"idModel"`
+		ModelType string          `json:"modelType"`
+		Name      string          `json:"name"`
+		Type      string          `json:"type"`
+		Pos       json.RawMessage `json:"pos"`
+		CardFront bool            `json:"display_cardFront"`
+		Options   []optionInput   `json:"options"`
+	}
+	if err := decodeJSON(r, &in); err != nil {
+		return nil, err
+	}
+	b, err := s.board(in.IDModel)
+	if err != nil || in.ModelType != "board" {
+		return nil, errInvalid("value for idModel")
+	}
+	if in.Name == "" {
+		return nil, errInvalid("value for name")
+	}
+	if !contains(customFieldTypes, in.Type) {
+		return nil, errInvalid("value for type")
+	}
+	positions := []float64{}
+	for _, f := range s.boardCustomFields(b) {
+		positions = append(positions, f.Pos)
+	}
+	pos, err := nextPos(positions, jsonPos(in.Pos))
+	if err != nil {
+		return nil, err
+	}
+	f := &customField{ID: s.newID(), IDModel: b.ID, ModelType: "board", FieldGroup: s.newID(), Name: in.Name, Pos: pos, Type: in.Type}
+	f.Display.CardFront = in.CardFront
+	if f.Type == "list" {
+		f.Options = []*customFieldOption{}
+		for _, option := range in.Options {
+			if _, err := s.addOption(f, option); err != nil {
+				return nil, err
+			}
+		}
+	}
+	s.customFields[f.ID] = f
+	s.addAction("createCustomField", map[string]interface{}{
+		"board":       boardRef(b),
+		"customField": customFieldRef(f),
+	})
+	return f, nil
+}
+
+func customFieldRef(f *customField) map[string]interface{} {
+	return map[string]interface{}{"id": f.ID, "name": f.Name, "type": f.Type}
+}
+
+func (s *Server) updateCustomField(f *customField, r *http.Request) (interface{}, error) {
+	old := map[string]interface{}{}
+	if values, ok := r.Form["name"]; ok {
+		if values[0] == "" {
+			return nil, errInvalid("value for name")
+		}
+		old["name"], f.Name = f.Name, values[0]
+	}
+	if values, ok := r.Form["display/cardFront"]; ok {
+		show, err := parseBool(values[0], false)
+		if err != nil {
+			return nil, err
+		}
+		old["display"], f.Display.CardFront = map[string]interface{}{"cardFront": f.Display.CardFront}, show
+	}
+	if values, ok := r.Form["pos"]; ok {
+		positions := []float64{}
+		for _, other := range s.boardCustomFields(s.boards[f.IDModel]) {
+			if other.ID != f.ID {
+				positions = append(positions, other.Pos)
+			}
+		}
+		pos, err := nextPos(positions, values[0])
+		if err != nil {
+			return nil, err
+		}
+		old["pos"], f.Pos = f.Pos, pos
+	}
+	if len(old) > 0 {
+		s.addAction("updateCustomField", map[string]interface{}{
+			"board":       boardRef(s.boards[f.IDModel]),
+			"customField": customFieldRef(f),
+			"old":         old,
+		})
+	}
+	return f, nil
+}
+
+func (s *Server) routeCustomFields(r *http.Request, seg []string) (interface{}, error) {
+	if len(seg) == 0 {
+		if r.Method == http.MethodPost {
+			return s.postCustomField(r)
+		}
+		return nil, errCannot(r)
+	}
+	f, err := s.customField(seg[0])
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case len(seg) == 1 && r.Method == http.MethodGet:
+		return f, nil
+	case len(seg) == 1 && r.Method == http.MethodPut:
+		return s.updateCustomField(f, r)
+	case len(seg) == 1 && r.Method == http.MethodDelete:
+		delete(s.customFields, f.ID)
+		s.deleteCustomFieldItems(func(item *customFieldItem) bool { return item.IDCustomField == f.ID })
+		s.addAction("deleteCustomField", map[string]interface{}{
+			"board":       boardRef(s.boards[f.IDModel]),
+			"customField": customFieldRef(f),
+		})
+		return limits(), nil
+	}
+	if seg[1] != "options" || f.Type != "list" {
+		return nil, errCannot(r)
+	}
+	switch {
+	case len(seg) == 2 && r.Method == http.MethodGet:
+		return f.Options, nil
+	case len(seg) == 2 && r.Method == http.MethodPost:
+		var in optionInput
+		if err := decodeJSON(r, &in); err != nil {
+			return nil, err
+		}
+		return s.addOption(f, in)
+	case len(seg) == 3:
+		for i, o := range f.Options {
+			if o.ID != seg[2] {
+				continue
+			}
+			switch r.Method {
+			case http.MethodGet:
+				return o, nil
+			case http.MethodDelete:
+				f.Options = append(f.Options[:i:i], f.Options[i+1:]...)
+				s.deleteCustomFieldItems(func(item *customFieldItem) bool {
+					return item.IDValue != nil && *item.IDValue == o.ID
+				})
+				return limits(), nil
+			}
+			return nil, errCannot(r)
+		}
+		return nil, errNotFound()
+	}
+	return nil, errCannot(r)
+}
+
+// customFieldValue - Check that value holds the single key matching the type of f, normalizing it
+func customFieldValue(f *customField, value map[string]string) (map[string]string, error) {
+	key := f.Type
+	if key == "checkbox" {
+		key = "checked"
+	}
+	v, ok := value[key]
+	if !ok || len(value) != 1 {
+		return nil, errInvalid("custom field value for type " + f.Type)
+	}
+	switch f.Type {
+	case "number":
+		if _, err := strconv.ParseFloat(v, 64); err != nil {
+			return nil, errInvalid("custom field value for type number")
+		}
+	case "date":
+		t, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			return nil, errInvalid("custom field value for type date")
+		}
+		v = t.UTC().Format(dateFormat)
+	case "checkbox":
+		if v != "true" && v != "false" {
+			return nil, errInvalid("custom field value for type checkbox")
+		}
+	}
+	return map[string]string{key: v}, nil
+}
+
+// putCustomFieldItem - Set (or clear, with an empty value and idValue) the value of a field on a card
+func (s *Server) putCustomFieldItem(r *http.Request, c *card, f *customField) (interface{}, error) {
+	if f.IDModel != c.IDBoard {
+		return nil, errInvalid("custom field for this card")
+	}
+	var in struct {
+		Value   json.RawMessage `json:"value"`
+		IDValue string          `json:"idValue"`
+	}
+	if err := decodeJSON(r, &in); err != nil {
+		return nil, err
+	}
+	var value map[string]string
+	if len(in.Value) > 0 && string(in.Value) != `""` && string(in.Value) != "null" {
+		if err := json.Unmarshal(in.Value, &value); err != nil {
+			return nil, errInvalid("custom field value")
+		}
+	}
+
+	item := s.cardCustomFieldItem(c, f)
+	updated := &customFieldItem{IDCustomField: f.ID, IDModel: c.ID, ModelType: "card"}
+	switch {
+	case f.Type == "list" && in.IDValue != "":
+		found := false
+		for _, o := range f.Options {
+			found = found || o.ID == in.IDValue
+		}
+		if !found {
+			return nil, errInvalid("value for idValue")
+		}
+		updated.IDValue = &in.IDValue
+	case f.Type != "list" && value != nil:
+		normalized, err := customFieldValue(f, value)
+		if err != nil {
+			return nil, err
+		}
+		updated.Value = normalized
+	case in.IDValue == "" && value == nil:
+		// Clearing the value removes the item
+		updated = nil
+	default:
+		return nil, errInvalid("custom field value for type " + f.Type)
+	}
+
+	data := s.cardData(c)
+	data["customField"] = customFieldRef(f)
+	if item != nil {
+		data["old"] = map[string]interface{}{"value": item.Value, "idValue": item.IDValue}
+		delete(s.fieldItems, item.ID)
+	}
+	if updated == nil {
+		data["customFieldItem"] = map[string]interface{}{"id": "", "idCustomField": f.ID, "idModel": c.ID, "modelType": "card"}
+		s.addAction("updateCustomFieldItem", data)
+		return map[string]interface{}{"idCustomField": f.ID, "idModel": c.ID, "modelType": "card"}, nil
+	}
+	updated.ID = s.newID()
+	if item != nil {
+		updated.ID = item.ID
+	}
+	s.fieldItems[updated.ID] = updated
+	data["customFieldItem"] = updated
+	s.addAction("updateCustomFieldItem", data)
+	return updated, nil
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trellotest
+
+import "net/http"
+
+func (s *Server) createLabel(b *board, name, color string) *label {
+	l := &label{ID: s.newID(), IDBoard: b.ID, Name: name}
+	if color != "" && color != "null" {
+		l.Color = &color
+	}
+	s.labels[l.ID] = l
+	return l
+}
+
+func (s *Server) routeLabels(r *http.Request, seg []string) (interface{}, error) {
+	if len(seg) == 0 {
+		if r.Method != http.MethodPost {
+			return nil, errCannot(r)
+		}
+		b, err := s.board(r.Form.Get("idBoard"))
+		if err != nil {
+			return nil, errInvalid("value for idBoard")
+		}
+		return s.createLabel(b, r.Form.Get("name"), r.Form.Get("color")), nil
+	}
+	if err := validID(seg[0]); err != nil {
+		return nil, err
+	}
+	l, ok := s.labels[seg[0]]
+	if !ok {
+		return nil, errNotFound()
+	}
+	switch {
+	case len(seg) == 1 && r.Method == http.MethodGet:
+		return l, nil
+	case len(seg) == 1 && r.Method == http.MethodDelete:
+		delete(s.labels, l.ID)
+		for _, c := range s.cards {
+			c.IDLabels = remove(c.IDLabels, l.ID)
+		}
+		return limits(), nil
+	case len(seg) == 1 && r.Method == http.MethodPut:
+		return s.updateLabel(l, r.Form)
+	case len(seg) == 2 && r.Method == http.MethodPut:
+		return s.updateLabel(l, map[string][]string{seg[1]: {r.Form.Get("value")}})
+	}
+	return nil, errCannot(r)
+}
+
+func (s *Server) updateLabel(l *label, form map[string][]string) (interface{}, error) {
+	if values, ok := form["name"]; ok && len(values) > 0 {
+		l.Name = values[0]
+	}
+	if values, ok := form["color"]; ok && len(values) > 0 {
+		if color := values[0]; color == "" || color == "null" {
+			l.Color = nil
+		} else {
+			l.Color = &color
+		}
+	}
+	return l, nil
+}
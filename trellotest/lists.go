@@ -0,0 +1,159 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trellotest
+
+import (
+	"net/http"
+	"sort"
+)
+
+func (s *Server) list(id string) (*list, error) {
+	if err := validID(id); err != nil {
+		return nil, err
+	}
+	if l, ok := s.lists[id]; ok {
+		return l, nil
+	}
+	return nil, errNotFound()
+}
+
+// listCards - Cards in a list sorted by position
+func (s *Server) listCards(l *list) []*card {
+	cards := []*card{}
+	for _, c := range s.cards {
+		if c.IDList == l.ID {
+			cards = append(cards, c)
+		}
+	}
+	sort.Slice(cards, func(i, j int) bool { return cards[i].Pos < cards[j].Pos })
+	return cards
+}
+
+func (s *Server) createList(b *board, name, pos string) *list {
+	existing := []float64{}
+	for _, l := range s.boardLists(b) {
+		existing = append(existing, l.Pos)
+	}
+	p, err := nextPos(existing, pos)
+	if err != nil {
+		p, _ = nextPos(existing, "bottom")
+	}
+	l := &list{ID: s.newID(), Name: name, IDBoard: b.ID, Pos: p}
+	s.lists[l.ID] = l
+	s.addAction("createList", map[string]interface{}{"board": boardRef(b), "list": listRef(l)})
+	return l
+}
+
+func (s *Server) postList(r *http.Request) (interface{}, error) {
+	if r.Form.Get("name") == "" {
+		return nil, errInvalid("value for name")
+	}
+	b, err := s.board(r.Form.Get("idBoard"))
+	if err != nil {
+		return nil, errInvalid("value for idBoard")
+	}
+	if _, err := nextPos(nil, r.Form.Get("pos")); err != nil {
+		return nil, err
+	}
+	return s.createList(b, r.Form.Get("name"), r.Form.Get("pos")), nil
+}
+
+func (s *Server) updateList(l *list, form map[string][]string) (interface{}, error) {
+	old := map[string]interface{}{}
+	for field, values := range form {
+		if len(values) == 0 {
+			continue
+		}
+		value := values[0]
+		switch field {
+		case "name":
+			if value == "" {
+				return nil, errInvalid("value for name")
+			}
+			old["name"], l.Name = l.Name, value
+		case "closed":
+			closed, err := parseBool(value, false)
+			if err != nil {
+				return nil, err
+			}
+			old["closed"], l.Closed = l.Closed, closed
+		case "pos":
+			existing := []float64{}
+			for _, other := range s.boardLists(s.boards[l.IDBoard]) {
+				if other.ID != l.ID {
+					existing = append(existing, other.Pos)
+				}
+			}
+			pos, err := nextPos(existing, value)
+			if err != nil {
+				return nil, err
+			}
+			old["pos"], l.Pos = l.Pos, pos
+		case "idBoard":
+			b, err := s.board(value)
+			if err != nil {
+				return nil, errInvalid("value for idBoard")
+			}
+			old["idBoard"], l.IDBoard = l.IDBoard, b.ID
+			for _, c := range s.listCards(l) {
+				c.IDBoard = b.ID
+			}
+		}
+	}
+	if len(old) > 0 {
+		s.addAction("updateList", map[string]interface{}{
+			"board": boardRef(s.boards[l.IDBoard]),
+			"list":  listRef(l),
+			"old":   old,
+		})
+	}
+	return l, nil
+}
+
+func (s *Server) routeLists(r *http.Request, seg []string) (interface{}, error) {
+	if len(seg) == 0 {
+		if r.Method == http.MethodPost {
+			return s.postList(r)
+		}
+		return nil, errCannot(r)
+	}
+	l, err := s.list(seg[0])
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case len(seg) == 1 && r.Method == http.MethodGet:
+		return s.renderNestedList(l, r.Form)
+	case len(seg) == 1 && r.Method == http.MethodPut:
+		return s.updateList(l, r.Form)
+	case len(seg) == 2 && seg[1] == "cards" && r.Method == http.MethodGet:
+		cards := []*card{}
+		for _, c := range s.listCards(l) {
+			if !c.Closed {
+				cards = append(cards, c)
+			}
+		}
+		return s.renderNestedCards(cards, r.Form)
+	case len(seg) == 2 && seg[1] == "actions" && r.Method == http.MethodGet:
+		return s.listActions(r, func(a *action) bool {
+			return dataID(a, "list") == l.ID || dataID(a, "listBefore") == l.ID || dataID(a, "listAfter") == l.ID
+		})
+	case len(seg) == 2 && r.Method == http.MethodPut:
+		return s.updateList(l, map[string][]string{seg[1]: {r.Form.Get("value")}})
+	}
+	return nil, errCannot(r)
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trellotest
+
+import (
+	"crypto/md5"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+func (s *Server) addMember(username, fullName string) *member {
+	initials := ""
+	for _, word := range strings.Fields(fullName) {
+		initials += strings.ToUpper(word[:1])
+	}
+	m := &member{
+		ID:              s.newID(),
+		Username:        username,
+		FullName:        fullName,
+		Initials:        initials,
+		AvatarHash:      fmt.Sprintf("%x", md5.Sum([]byte(username))),
+		Confirmed:       true,
+		MemberType:      "normal",
+		Status:          "disconnected",
+		URL:             "https://trello.com/" + username,
+		IDBoards:        []string{},
+		IDOrganizations: []string{},
+	}
+	s.members[m.ID] = m
+	return m
+}
+
+// member - Find a member by ID, username or "me"
+func (s *Server) member(idOrName string) (*member, error) {
+	if idOrName == "me" {
+		idOrName = s.meID
+	}
+	if m, ok := s.members[idOrName]; ok {
+		return m, nil
+	}
+	for _, m := range s.members {
+		if m.Username == idOrName {
+			return m, nil
+		}
+	}
+	return nil, errNotFound()
+}
+
+func (s *Server) me() *member {
+	return s.members[s.meID]
+}
+
+func (s *Server) memberList(ids []string) []*member {
+	members := []*member{}
+	for _, id := range ids {
+		if m, ok := s.members[id]; ok {
+			members = append(members, m)
+		}
+	}
+	return members
+}
+
+func (s *Server) routeMembers(r *http.Request, seg []string) (interface{}, error) {
+	if len(seg) == 0 {
+		return nil, errCannot(r)
+	}
+	m, err := s.member(seg[0])
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case len(seg) == 1 && r.Method == http.MethodGet:
+		return selectFields(m, r.Form.Get("fields"))
+	case len(seg) == 2 && seg[1] == "boards" && r.Method == http.MethodGet:
+		boards := []interface{}{}
+		for _, b := range s.sortedBoards() {
+			if b.membership(m.ID) == nil {
+				continue
+			}
+			v, err := selectFields(b, r.Form.Get("fields"))
+			if err != nil {
+				return nil, err
+			}
+			boards = append(boards, v)
+		}
+		return boards, nil
+	case len(seg) == 2 && seg[1] == "notifications" && r.Method == http.MethodGet:
+		notifications := []*notification{}
+		for _, n := range s.notifications {
+			if n.idMember == m.ID {
+				notifications = append(notifications, n)
+			}
+		}
+		return notifications, nil
+	case len(seg) == 2 && seg[1] == "actions" && r.Method == http.MethodGet:
+		return s.listActions(r, func(a *action) bool {
+			return a.IDMemberCreator == m.ID
+		})
+	}
+	return nil, errCannot(r)
+}
+
+func (s *Server) routeOrganizations(r *http.Request, seg []string) (interface{}, error) {
+	if len(seg) == 0 {
+		return nil, errCannot(r)
+	}
+	org, ok := s.organizations[seg[0]]
+	if !ok {
+		for _, o := range s.organizations {
+			if o.Name == seg[0] {
+				org, ok = o, true
+			}
+		}
+	}
+	if !ok {
+		return nil, errNotFound()
+	}
+	switch {
+	case len(seg) == 1 && r.Method == http.MethodGet:
+		return org, nil
+	case len(seg) == 2 && seg[1] == "members" && r.Method == http.MethodGet:
+		members := []*member{}
+		for _, m := range s.members {
+			if contains(m.IDOrganizations, org.ID) {
+				members = append(members, m)
+			}
+		}
+		return members, nil
+	case len(seg) == 2 && seg[1] == "boards" && r.Method == http.MethodGet:
+		boards := []*board{}
+		for _, b := range s.sortedBoards() {
+			if b.IDOrganization != nil && *b.IDOrganization == org.ID {
+				boards = append(boards, b)
+			}
+		}
+		return boards, nil
+	case len(seg) == 2 && seg[1] == "actions" && r.Method == http.MethodGet:
+		return s.listActions(r, func(a *action) bool {
+			b, ok := s.boards[dataID(a, "board")]
+			return ok && b.IDOrganization != nil && *b.IDOrganization == org.ID
+		})
+	}
+	return nil, errCannot(r)
+}
+
+func (s *Server) routeNotifications(r *http.Request, seg []string) (interface{}, error) {
+	if len(seg) == 1 && r.Method == http.MethodGet {
+		if err := validID(seg[0]); err != nil {
+			return nil, err
+		}
+		if n, ok := s.notifications[seg[0]]; ok {
+			return n, nil
+		}
+		return nil, errNotFound()
+	}
+	return nil, errCannot(r)
+}
@@ -0,0 +1,293 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trellotest
+
+// The types below mirror the JSON representations returned by Trello.
+
+type member struct {
+	ID              string   `json:"id"`
+	Username        string   `json:"username"`
+	FullName        string   `json:"fullName"`
+	Initials        string   `json:"initials"`
+	AvatarHash      string   `json:"avatarHash"`
+	Bio             string   `json:"bio"`
+	Confirmed       bool     `json:"confirmed"`
+	MemberType      string   `json:"memberType"`
+	Status          string   `json:"status"`
+	URL             string   `json:"url"`
+	Email           string   `json:"email"`
+	IDBoards        []string `json:"idBoards"`
+	IDOrganizations []string `json:"idOrganizations"`
+}
+
+type memberCreator struct {
+	ID         string `json:"id"`
+	AvatarHash string `json:"avatarHash"`
+	FullName   string `json:"fullName"`
+	Initials   string `json:"initials"`
+	Username   string `json:"username"`
+}
+
+func (m *member) creator() *memberCreator {
+	return &memberCreator{
+		ID:         m.ID,
+		AvatarHash: m.AvatarHash,
+		FullName:   m.FullName,
+		Initials:   m.Initials,
+		Username:   m.Username,
+	}
+}
+
+type organization struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	DisplayName string   `json:"displayName"`
+	Desc        string   `json:"desc"`
+	URL         string   `json:"url"`
+	Website     string   `json:"website"`
+	LogoHash    *string  `json:"logoHash"`
+	Products    []string `json:"products"`
+	PowerUps    []string `json:"powerUps"`
+}
+
+type membership struct {
+	ID          string  `json:"id"`
+	IDMember    string  `json:"idMember"`
+	MemberType  string  `json:"memberType"`
+	Unconfirmed bool    `json:"unconfirmed"`
+	Deactivated bool    `json:"deactivated"`
+	Member      *member `json:"member,omitempty"`
+}
+
+type boardPrefs struct {
+	PermissionLevel     string `json:"permissionLevel"`
+	Voting              string `json:"voting"`
+	Comments            string `json:"comments"`
+	Invitations         string `json:"invitations"`
+	SelfJoin            bool   `json:"selfJoin"`
+	CardCovers          bool   `json:"cardCovers"`
+	CardAging           string `json:"cardAging"`
+	CalendarFeedEnabled bool   `json:"calendarFeedEnabled"`
+	Background          string `json:"background"`
+	BackgroundColor     string `json:"backgroundColor"`
+	BackgroundTile      bool   `json:"backgroundTile"`
+	CanBePublic         bool   `json:"canBePublic"`
+	CanBeOrg            bool   `json:"canBeOrg"`
+	CanBePrivate        bool   `json:"canBePrivate"`
+	CanInvite           bool   `json:"canInvite"`
+}
+
+type board struct {
+	ID               string            `json:"id"`
+	Name             string            `json:"name"`
+	Desc             string            `json:"desc"`
+	DescData         interface{}       `json:"descData"`
+	Closed           bool              `json:"closed"`
+	IDOrganization   *string           `json:"idOrganization"`
+	IDMemberCreator  string            `json:"idMemberCreator"`
+	Pinned           bool              `json:"pinned"`
+	URL              string            `json:"url"`
+	ShortURL         string            `json:"shortUrl"`
+	ShortLink        string            `json:"shortLink"`
+	DateLastActivity string            `json:"dateLastActivity"`
+	Prefs            boardPrefs        `json:"prefs"`
+	LabelNames       map[string]string `json:"labelNames"`
+	Memberships      []*membership     `json:"memberships"`
+	cardSeq          int
+}
+
+type list struct {
+	ID         string  `json:"id"`
+	Name       string  `json:"name"`
+	Closed     bool    `json:"closed"`
+	IDBoard    string  `json:"idBoard"`
+	Pos        float64 `json:"pos"`
+	Subscribed bool    `json:"subscribed"`
+}
+
+type label struct {
+	ID      string  `json:"id"`
+	IDBoard string  `json:"idBoard"`
+	Name    string  `json:"name"`
+	Color   *string `json:"color"`
+}
+
+type badges struct {
+	Votes             int     `json:"votes"`
+	Subscribed        bool    `json:"subscribed"`
+	CheckItems        int     `json:"checkItems"`
+	CheckItemsChecked int     `json:"checkItemsChecked"`
+	Comments          int     `json:"comments"`
+	Attachments       int     `json:"attachments"`
+	Description       bool    `json:"description"`
+	Due               *string `json:"due"`
+	DueComplete       bool    `json:"dueComplete"`
+	Start             *string `json:"start"`
+}
+
+type card struct {
+	ID                    string        `json:"id"`
+	Name                  string        `json:"name"`
+	Desc                  string        `json:"desc"`
+	DescData              interface{}   `json:"descData"`
+	Closed                bool          `json:"closed"`
+	IDBoard               string        `json:"idBoard"`
+	IDList                string        `json:"idList"`
+	IDMembers             []string      `json:"idMembers"`
+	IDMembersVoted        []string      `json:"idMembersVoted"`
+	IDLabels              []string      `json:"idLabels"`
+	IDChecklists          []string      `json:"idChecklists"`
+	IDAttachmentCover     *string       `json:"idAttachmentCover"`
+	ManualCoverAttachment bool          `json:"manualCoverAttachment"`
+	Labels                []*label      `json:"labels"`
+	Pos                   float64       `json:"pos"`
+	Due                   *string       `json:"due"`
+	DueComplete           bool          `json:"dueComplete"`
+	Start                 *string       `json:"start"`
+	Address               *string       `json:"address"`
+	LocationName          *string       `json:"locationName"`
+	Coordinates           *coordinates  `json:"coordinates"`
+	DateLastActivity      string        `json:"dateLastActivity"`
+	IDShort               int           `json:"idShort"`
+	ShortLink             string        `json:"shortLink"`
+	ShortURL              string        `json:"shortUrl"`
+	URL                   string        `json:"url"`
+	Email                 *string       `json:"email"`
+	Subscribed            bool          `json:"subscribed"`
+	Badges                badges        `json:"badges"`
+	CheckItemStates       []interface{} `json:"checkItemStates"`
+}
+
+type coordinates struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+type checkItem struct {
+	ID          string      `json:"id"`
+	Name        string      `json:"name"`
+	NameData    interface{} `json:"nameData"`
+	Pos         float64     `json:"pos"`
+	State       string      `json:"state"`
+	IDChecklist string      `json:"idChecklist"`
+	Due         *string     `json:"due"`
+	IDMember    *string     `json:"idMember"`
+}
+
+type checklist struct {
+	ID         string       `json:"id"`
+	Name       string       `json:"name"`
+	IDBoard    string       `json:"idBoard"`
+	IDCard     string       `json:"idCard"`
+	Pos        float64      `json:"pos"`
+	CheckItems []*checkItem `json:"checkItems"`
+}
+
+type action struct {
+	ID              string                 `json:"id"`
+	IDMemberCreator string                 `json:"idMemberCreator"`
+	Type            string                 `json:"type"`
+	Date            string                 `json:"date"`
+	Data            map[string]interface{} `json:"data"`
+	MemberCreator   *memberCreator         `json:"memberCreator"`
+	reactions       []*reaction
+}
+
+type attachment struct {
+	ID        string        `json:"id"`
+	Bytes     *int          `json:"bytes"`
+	Date      string        `json:"date"`
+	EdgeColor *string       `json:"edgeColor"`
+	IDMember  string        `json:"idMember"`
+	IsUpload  bool          `json:"isUpload"`
+	MimeType  string        `json:"mimeType"`
+	Name      string        `json:"name"`
+	Previews  []interface{} `json:"previews"`
+	URL       string        `json:"url"`
+	Pos       float64       `json:"pos"`
+	FileName  string        `json:"fileName"`
+	idCard    string
+	data      []byte
+}
+
+type reaction struct {
+	ID       string         `json:"id"`
+	IDMember string         `json:"idMember"`
+	IDModel  string         `json:"idModel"`
+	IDEmoji  string         `json:"idEmoji"`
+	Member   *memberCreator `json:"member,omitempty"`
+	Emoji    *emoji         `json:"emoji,omitempty"`
+}
+
+type emoji struct {
+	Unified   string `json:"unified"`
+	Native    string `json:"native"`
+	Name      string `json:"name"`
+	ShortName string `json:"shortName"`
+}
+
+type notification struct {
+	ID              string                 `json:"id"`
+	Unread          bool                   `json:"unread"`
+	Type            string                 `json:"type"`
+	Date            string                 `json:"date"`
+	Data            map[string]interface{} `json:"data"`
+	IDMemberCreator string                 `json:"idMemberCreator"`
+	MemberCreator   *memberCreator         `json:"memberCreator"`
+	idMember        string
+}
+
+type webhook struct {
+	ID                       string  `json:"id"`
+	Description              string  `json:"description"`
+	IDModel                  string  `json:"idModel"`
+	CallbackURL              string  `json:"callbackURL"`
+	Active                   bool    `json:"active"`
+	ConsecutiveFailures      int     `json:"consecutiveFailures"`
+	FirstConsecutiveFailDate *string `json:"firstConsecutiveFailDate"`
+}
+
+type customField struct {
+	ID         string `json:"id"`
+	IDModel    string `json:"idModel"`
+	ModelType  string `json:"modelType"`
+	FieldGroup string `json:"fieldGroup"`
+	Display    struct {
+		CardFront bool `json:"cardFront"`
+	} `json:"display"`
+	Name    string               `json:"name"`
+	Pos     float64              `json:"pos"`
+	Options []*customFieldOption `json:"options,omitempty"`
+	Type    string               `json:"type"`
+}
+
+type customFieldOption struct {
+	ID            string            `json:"id"`
+	IDCustomField string            `json:"idCustomField"`
+	Value         map[string]string `json:"value"`
+	Color         string            `json:"color"`
+	Pos           float64           `json:"pos"`
+}
+
+type customFieldItem struct {
+	ID            string            `json:"id"`
+	IDValue       *string           `json:"idValue,omitempty"`
+	Value         map[string]string `json:"value,omitempty"`
+	IDCustomField string            `json:"idCustomField"`
+	IDModel       string            `json:"idModel"`
+	ModelType     string            `json:"modelType"`
+}
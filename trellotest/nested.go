@@ -0,0 +1,221 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trellotest
+
+import (
+	"encoding/json"
+	"net/url"
+)
+
+// Number of actions nested in a board or card
+const nestedActionLimit = 50
+
+// withNested - Render v with the requested fields and the nested resources added
+func withNested(v interface{}, fields string, nested map[string]interface{}) (interface{}, error) {
+	selected, err := selectFields(v, fields)
+	if err != nil || len(nested) == 0 {
+		return selected, err
+	}
+	body, err := json.Marshal(selected)
+	if err != nil {
+		return nil, err
+	}
+	rendered := map[string]interface{}{}
+	if err = json.Unmarshal(body, &rendered); err != nil {
+		return nil, err
+	}
+	for name, value := range nested {
+		rendered[name] = value
+	}
+	return rendered, nil
+}
+
+// cardFilter - Parse a nested cards filter (nil if no cards were requested)
+func cardFilter(filter string) (func(c *card) bool, error) {
+	switch filter {
+	case "", "none":
+		return nil, nil
+	case "all":
+		return func(c *card) bool { return true }, nil
+	case "open", "visible":
+		return func(c *card) bool { return !c.Closed }, nil
+	case "closed":
+		return func(c *card) bool { return c.Closed }, nil
+	}
+	return nil, errInvalid("value for cards")
+}
+
+// nestedCards - Render the cards matching the filter
+func (s *Server) nestedCards(cards []*card, filter string) (interface{}, error) {
+	match, err := cardFilter(filter)
+	if match == nil || err != nil {
+		return nil, err
+	}
+	rendered := []*card{}
+	for _, c := range cards {
+		if match(c) {
+			rendered = append(rendered, s.renderCard(c))
+		}
+	}
+	return rendered, nil
+}
+
+// nestedActions - The latest actions of the given types ("all" for any type) matching pred
+func (s *Server) nestedActions(types string, pred func(*action) bool) []*action {
+	filter := splitIDs(types)
+	if len(filter) == 1 && filter[0] == "all" {
+		filter = nil
+	}
+	actions := []*action{}
+	for i := len(s.actions) - 1; i >= 0 && len(actions) < nestedActionLimit; i-- {
+		a := s.actions[i]
+		if pred(a) && (len(filter) == 0 || contains(filter, a.Type)) {
+			actions = append(actions, a)
+		}
+	}
+	return actions
+}
+
+// renderNestedCard - Render a card with the fields and nested resources requested in form
+func (s *Server) renderNestedCard(c *card, form url.Values) (interface{}, error) {
+	nested := map[string]interface{}{}
+	switch form.Get("checklists") {
+	case "", "none":
+	case "all":
+		nested["checklists"] = s.cardChecklists(c)
+	default:
+		return nil, errInvalid("value for checklists")
+	}
+	if members, err := parseBool(form.Get("members"), false); err != nil {
+		return nil, err
+	} else if members {
+		nested["members"] = s.memberList(c.IDMembers)
+	}
+	switch form.Get("attachments") {
+	case "", "false":
+	case "true":
+		nested["attachments"] = s.cardAttachments(c)
+	case "cover":
+		attachments := []*attachment{}
+		for _, a := range s.cardAttachments(c) {
+			if c.IDAttachmentCover != nil && *c.IDAttachmentCover == a.ID {
+				attachments = append(attachments, a)
+			}
+		}
+		nested["attachments"] = attachments
+	default:
+		return nil, errInvalid("value for attachments")
+	}
+	if items, err := parseBool(form.Get("customFieldItems"), false); err != nil {
+		return nil, err
+	} else if items {
+		nested["customFieldItems"] = s.cardCustomFieldItems(c)
+	}
+	if types := form.Get("actions"); types != "" {
+		nested["actions"] = s.nestedActions(types, func(a *action) bool { return dataID(a, "card") == c.ID })
+	}
+	return withNested(s.renderCard(c), form.Get("fields"), nested)
+}
+
+// renderNestedCards - Render cards with the fields and nested resources requested in form
+func (s *Server) renderNestedCards(cards []*card, form url.Values) (interface{}, error) {
+	rendered := []interface{}{}
+	for _, c := range cards {
+		v, err := s.renderNestedCard(c, form)
+		if err != nil {
+			return nil, err
+		}
+		rendered = append(rendered, v)
+	}
+	return rendered, nil
+}
+
+// renderNestedList - Render a list with the fields and nested cards requested in form
+func (s *Server) renderNestedList(l *list, form url.Values) (interface{}, error) {
+	nested := map[string]interface{}{}
+	cards, err := s.nestedCards(s.listCards(l), form.Get("cards"))
+	if err != nil {
+		return nil, err
+	}
+	if cards != nil {
+		nested["cards"] = cards
+	}
+	return withNested(l, form.Get("fields"), nested)
+}
+
+// renderNestedBoard - Render a board with the fields and nested resources requested in form
+func (s *Server) renderNestedBoard(b *board, form url.Values) (interface{}, error) {
+	nested := map[string]interface{}{}
+	switch filter := form.Get("lists"); filter {
+	case "", "none":
+	case "all", "open", "closed":
+		lists := []*list{}
+		for _, l := range s.boardLists(b) {
+			if filter == "all" || (filter == "closed") == l.Closed {
+				lists = append(lists, l)
+			}
+		}
+		nested["lists"] = lists
+	default:
+		return nil, errInvalid("value for lists")
+	}
+	cards, err := s.nestedCards(s.boardCards(b), form.Get("cards"))
+	if err != nil {
+		return nil, err
+	}
+	if cards != nil {
+		nested["cards"] = cards
+	}
+	switch form.Get("checklists") {
+	case "", "none":
+	case "all":
+		checklists := []*checklist{}
+		for _, c := range s.boardCards(b) {
+			checklists = append(checklists, s.cardChecklists(c)...)
+		}
+		nested["checklists"] = checklists
+	default:
+		return nil, errInvalid("value for checklists")
+	}
+	switch form.Get("labels") {
+	case "", "none":
+	case "all":
+		nested["labels"] = s.boardLabels(b)
+	default:
+		return nil, errInvalid("value for labels")
+	}
+	switch filter := form.Get("members"); filter {
+	case "", "none":
+	case "all", "normal", "admins", "owners":
+		ids := []string{}
+		for _, ms := range b.Memberships {
+			switch {
+			case filter == "all",
+				filter == "normal" && ms.MemberType == "normal",
+				(filter == "admins" || filter == "owners") && ms.MemberType == "admin":
+				ids = append(ids, ms.IDMember)
+			}
+		}
+		nested["members"] = s.memberList(ids)
+	default:
+		return nil, errInvalid("value for members")
+	}
+	if types := form.Get("actions"); types != "" {
+		nested["actions"] = s.nestedActions(types, func(a *action) bool { return dataID(a, "board") == b.ID })
+	}
+	return withNested(b, form.Get("fields"), nested)
+}
@@ -0,0 +1,385 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package trellotest provides an in-memory fake of the Trello REST API for tests.
+//
+// The fake is an httptest.Server that implements boards, lists, cards, labels,
+// checklists, attachments, custom fields, members, memberships, organizations,
+// notifications, webhooks and actions with Trello shaped JSON, so tests can run
+// without network access:
+//
+//	server := trellotest.NewServer()
+//	defer server.Close()
+//	client, err := trello.NewClient(trello.WithBaseURL(server.Endpoint()))
+package trellotest
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Trello date format
+const dateFormat = "2006-01-02T15:04:05.000Z"
+
+// Seeded members
+const (
+	MeUsername     = "me-test"
+	TrelloUsername = "trello"
+	TestUsername   = "test"
+)
+
+var idPattern = regexp.MustCompile("^[0-9a-fA-F]{24}$")
+
+// Server - Fake Trello API server
+type Server struct {
+	*httptest.Server
+
+	// Key and Token, when set, must be present on every request
+	Key   string
+	Token string
+
+	mu            sync.Mutex
+	seq           uint64
+	meID          string
+	members       map[string]*member
+	organizations map[string]*organization
+	boards        map[string]*board
+	lists         map[string]*list
+	cards         map[string]*card
+	labels        map[string]*label
+	checklists    map[string]*checklist
+	notifications map[string]*notification
+	webhooks      map[string]*webhook
+	attachments   map[string]*attachment
+	customFields  map[string]*customField
+	fieldItems    map[string]*customFieldItem
+	actions       []*action
+}
+
+// httpError - Error response (Trello answers errors with plain text)
+type httpError struct {
+	status  int
+	message string
+}
+
+func (e *httpError) Error() string {
+	return e.message
+}
+
+func errNotFound() error {
+	return &httpError{http.StatusNotFound, "The requested resource was not found."}
+}
+
+func errInvalid(what string) error {
+	return &httpError{http.StatusBadRequest, "invalid " + what}
+}
+
+// NewServer - Start a fake Trello server seeded with three members ("me", "trello"
+// and "test"), an organization and a notification. Close it when done.
+func NewServer() *Server {
+	s := NewUnstartedServer()
+	s.Start()
+	return s
+}
+
+// NewUnstartedServer - Create a fake Trello server without starting it
+func NewUnstartedServer() *Server {
+	s := &Server{
+		members:       map[string]*member{},
+		organizations: map[string]*organization{},
+		boards:        map[string]*board{},
+		lists:         map[string]*list{},
+		cards:         map[string]*card{},
+		labels:        map[string]*label{},
+		checklists:    map[string]*checklist{},
+		notifications: map[string]*notification{},
+		webhooks:      map[string]*webhook{},
+		attachments:   map[string]*attachment{},
+		customFields:  map[string]*customField{},
+		fieldItems:    map[string]*customFieldItem{},
+	}
+	s.Server = httptest.NewUnstartedServer(s)
+	s.seed()
+	return s
+}
+
+// Endpoint - Base URL to pass to trello.WithBaseURL
+func (s *Server) Endpoint() string {
+	return s.URL + "/1"
+}
+
+// MeID - ID of the member returned for "me"
+func (s *Server) MeID() string {
+	return s.meID
+}
+
+// AddMember - Add a member that can be retrieved by ID or username, returning its ID
+func (s *Server) AddMember(username, fullName string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addMember(username, fullName).ID
+}
+
+func (s *Server) seed() {
+	me := s.addMember(MeUsername, "Test User")
+	s.meID = me.ID
+	s.addMember(TrelloUsername, "Trello")
+	s.addMember(TestUsername, "Test")
+
+	org := &organization{
+		ID:          s.newID(),
+		Name:        "gotrellotest",
+		DisplayName: "Go Trello Test",
+		Website:     "https://github.com/TJM/go-trello",
+		Products:    []string{},
+		PowerUps:    []string{},
+	}
+	org.URL = "https://trello.com/" + org.Name
+	s.organizations[org.ID] = org
+	me.IDOrganizations = append(me.IDOrganizations, org.ID)
+
+	n := &notification{
+		ID:              s.newID(),
+		Unread:          true,
+		Type:            "addedToCard",
+		Date:            s.date(),
+		IDMemberCreator: me.ID,
+		MemberCreator:   me.creator(),
+		idMember:        me.ID,
+		Data:            map[string]interface{}{},
+	}
+	s.notifications[n.ID] = n
+}
+
+// ServeHTTP - Route a request to the fake API
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result, err := s.route(r)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if he, ok := err.(*httpError); ok {
+			status = he.status
+		}
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.WriteHeader(status)
+		fmt.Fprint(w, err.Error())
+		return
+	}
+	if raw, ok := result.(*rawResponse); ok {
+		w.Header().Set("Content-Type", raw.contentType)
+		w.Write(raw.body)
+		return
+	}
+	body, err := json.Marshal(result)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Write(body)
+}
+
+func (s *Server) route(r *http.Request) (interface{}, error) {
+	parse := r.ParseForm
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		parse = func() error { return r.ParseMultipartForm(maxUpload) }
+	}
+	if err := parse(); err != nil {
+		return nil, errInvalid("request")
+	}
+	if s.Key != "" && r.Form.Get("key") != s.Key {
+		return nil, &httpError{http.StatusUnauthorized, "invalid key"}
+	}
+	if s.Token != "" && r.Form.Get("token") != s.Token {
+		return nil, &httpError{http.StatusUnauthorized, "invalid token"}
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/1/")
+	var seg []string
+	for _, part := range strings.Split(path, "/") {
+		if part != "" {
+			seg = append(seg, part)
+		}
+	}
+	if len(seg) == 0 {
+		return nil, errNotFound()
+	}
+
+	switch seg[0] {
+	case "boards":
+		return s.routeBoards(r, seg[1:])
+	case "lists":
+		return s.routeLists(r, seg[1:])
+	case "cards", "card":
+		return s.routeCards(r, seg[1:])
+	case "checklists", "checklist":
+		return s.routeChecklists(r, seg[1:])
+	case "labels":
+		return s.routeLabels(r, seg[1:])
+	case "customFields", "customField":
+		return s.routeCustomFields(r, seg[1:])
+	case "members":
+		return s.routeMembers(r, seg[1:])
+	case "organizations", "organization":
+		return s.routeOrganizations(r, seg[1:])
+	case "notifications":
+		return s.routeNotifications(r, seg[1:])
+	case "webhooks":
+		return s.routeWebhooks(r, seg[1:])
+	case "tokens":
+		return s.routeTokens(r, seg[1:])
+	case "actions":
+		return s.routeActions(r, seg[1:])
+	case "batch":
+		return s.routeBatch(r, seg[1:])
+	}
+	return nil, errCannot(r)
+}
+
+// newID - Generate a Trello style object ID (timestamp followed by a counter)
+func (s *Server) newID() string {
+	s.seq++
+	return fmt.Sprintf("%08x%016x", time.Now().Unix(), s.seq)
+}
+
+func (s *Server) date() string {
+	return time.Now().UTC().Format(dateFormat)
+}
+
+func shortLink() string {
+	const chars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	b := make([]byte, 8)
+	for i := range b {
+		b[i] = chars[rand.Intn(len(chars))]
+	}
+	return string(b)
+}
+
+func validID(id string) error {
+	if !idPattern.MatchString(id) {
+		return errInvalid("id")
+	}
+	return nil
+}
+
+// errCannot - Trello's response for unknown routes
+func errCannot(r *http.Request) error {
+	return &httpError{http.StatusNotFound, "Cannot " + r.Method + " " + r.URL.Path}
+}
+
+// limits - Response body Trello sends for deletes
+func limits() interface{} {
+	return map[string]interface{}{"limits": map[string]interface{}{}}
+}
+
+// nextPos - Position for a new item given the existing positions and the requested pos
+func nextPos(existing []float64, pos string) (float64, error) {
+	max, min := 0.0, 0.0
+	for i, p := range existing {
+		if i == 0 || p > max {
+			max = p
+		}
+		if i == 0 || p < min {
+			min = p
+		}
+	}
+	switch pos {
+	case "", "bottom":
+		return max + 16384, nil
+	case "top":
+		if len(existing) == 0 {
+			return 16384, nil
+		}
+		return float64(int(min / 2)), nil
+	}
+	p, err := strconv.ParseFloat(pos, 64)
+	if err != nil || p < 0 {
+		return 0, errInvalid("value for pos")
+	}
+	return p, nil
+}
+
+func parseBool(value string, def bool) (bool, error) {
+	if value == "" {
+		return def, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, errInvalid("value")
+	}
+	return b, nil
+}
+
+func splitIDs(value string) []string {
+	ids := []string{}
+	for _, id := range strings.Split(value, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func remove(values []string, value string) []string {
+	out := []string{}
+	for _, v := range values {
+		if v != value {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// selectFields - Render v keeping only the comma separated fields (and the id)
+func selectFields(v interface{}, fields string) (interface{}, error) {
+	if fields == "" || fields == "all" {
+		return v, nil
+	}
+	body, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	all := map[string]interface{}{}
+	if err = json.Unmarshal(body, &all); err != nil {
+		return nil, err
+	}
+	selected := map[string]interface{}{"id": all["id"]}
+	for _, f := range splitIDs(fields) {
+		if value, ok := all[f]; ok {
+			selected[f] = value
+		}
+	}
+	return selected, nil
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trellotest_test
+
+import (
+	"errors"
+	"testing"
+
+	trello "github.com/TJM/go-trello"
+	"github.com/TJM/go-trello/trellotest"
+	goblin "github.com/franela/goblin"
+	. "github.com/onsi/gomega"
+)
+
+func TestServer(t *testing.T) {
+	g := goblin.Goblin(t)
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+
+	g.Describe("Fake server tests", func() {
+		var server *trellotest.Server
+		var client *trello.Client
+		var board *trello.Board
+
+		g.Before(func() {
+			server = trellotest.NewServer()
+			client, _ = trello.NewClient(trello.WithBaseURL(server.Endpoint()))
+		})
+
+		g.After(func() {
+			server.Close()
+		})
+
+		g.It("should serve the seeded members", func() {
+			me, err := client.Member("me")
+			Expect(err).To(BeNil())
+			Expect(me.ID).To(Equal(server.MeID()))
+			Expect(me.Username).To(Equal(trellotest.MeUsername))
+			Expect(len(me.IDOrganizations)).To(Equal(1))
+
+			id := server.AddMember("someone", "Some One")
+			m, err := client.Member("someone")
+			Expect(err).To(BeNil())
+			Expect(m.ID).To(Equal(id))
+			Expect(m.Initials).To(Equal("SO"))
+		})
+
+		g.It("should create a board with the default lists and labels", func() {
+			var err error
+			board, err = client.CreateBoard("Fake Board")
+			Expect(err).To(BeNil())
+			Expect(board.ID).To(HaveLen(24))
+			lists, err := board.Lists()
+			Expect(err).To(BeNil())
+			Expect(lists).To(HaveLen(3))
+			Expect(lists[0].Name).To(Equal("To Do"))
+			labels, err := board.Labels()
+			Expect(err).To(BeNil())
+			Expect(labels).To(HaveLen(6))
+		})
+
+		g.It("should return Trello style errors", func() {
+			_, err := client.Board("000000000000000000000000")
+			Expect(errors.Is(err, trello.ErrNotFound)).To(BeTrue())
+			_, err = client.Card("invalid")
+			var apiErr *trello.APIError
+			Expect(errors.As(err, &apiErr)).To(BeTrue())
+			Expect(apiErr.StatusCode).To(Equal(400))
+			Expect(apiErr.Body).To(Equal("invalid id"))
+		})
+
+		g.It("should record and filter actions", func() {
+			lists, _ := board.Lists()
+			card, err := lists[0].AddCard(trello.Card{Name: "Card"})
+			Expect(err).To(BeNil())
+			Expect(card.MoveToList(lists[1])).To(BeNil())
+			Expect(card.IDList).To(Equal(lists[1].ID))
+
+			actions, err := board.Actions(trello.NewArgument("filter", "createCard,updateCard"))
+			Expect(err).To(BeNil())
+			Expect(actions).To(HaveLen(2))
+			Expect(actions[0].Type).To(Equal(trello.UpdateCard))
+			Expect(actions[0].Data.ListAfter.ID).To(Equal(lists[1].ID))
+			Expect(actions[1].Type).To(Equal(trello.CreateCard))
+
+			actions, err = board.Actions(trello.NewArgument("limit", "1"))
+			Expect(err).To(BeNil())
+			Expect(actions).To(HaveLen(1))
+		})
+
+		g.It("should copy cards when duplicating a board", func() {
+			dup, err := board.Duplicate("Copy", true)
+			Expect(err).To(BeNil())
+			cards, err := dup.Cards()
+			Expect(err).To(BeNil())
+			Expect(cards).To(HaveLen(1))
+			Expect(cards[0].IDBoard).To(Equal(dup.ID))
+		})
+
+		g.It("should require the key and token when configured", func() {
+			server.Key, server.Token = "key", "token"
+			defer func() { server.Key, server.Token = "", "" }()
+			_, err := client.Member("me")
+			Expect(errors.Is(err, trello.ErrUnauthorized)).To(BeTrue())
+
+			token := "token"
+			authClient, _ := trello.NewClient(trello.WithBaseURL(server.Endpoint()), trello.WithAuth("key", &token))
+			_, err = authClient.Member("me")
+			Expect(err).To(BeNil())
+		})
+	})
+}
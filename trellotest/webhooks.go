@@ -0,0 +1,169 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trellotest
+
+import (
+	"net/http"
+	"net/url"
+	"sort"
+)
+
+// modelExists - Webhooks can watch any model the token can read
+func (s *Server) modelExists(id string) bool {
+	if _, ok := s.members[id]; ok {
+		return true
+	}
+	if _, ok := s.boards[id]; ok {
+		return true
+	}
+	if _, ok := s.lists[id]; ok {
+		return true
+	}
+	if _, ok := s.cards[id]; ok {
+		return true
+	}
+	_, ok := s.organizations[id]
+	return ok
+}
+
+func (s *Server) webhook(id string) (*webhook, error) {
+	if err := validID(id); err != nil {
+		return nil, err
+	}
+	if w, ok := s.webhooks[id]; ok {
+		return w, nil
+	}
+	return nil, errNotFound()
+}
+
+func (s *Server) updateWebhook(w *webhook, form url.Values) (interface{}, error) {
+	if _, ok := form["callbackURL"]; ok {
+		if err := validCallbackURL(form.Get("callbackURL")); err != nil {
+			return nil, err
+		}
+		w.CallbackURL = form.Get("callbackURL")
+	}
+	if _, ok := form["description"]; ok {
+		w.Description = form.Get("description")
+	}
+	if _, ok := form["idModel"]; ok {
+		if !s.modelExists(form.Get("idModel")) {
+			return nil, errInvalid("value for idModel")
+		}
+		w.IDModel = form.Get("idModel")
+	}
+	if _, ok := form["active"]; ok {
+		active, err := parseBool(form.Get("active"), true)
+		if err != nil {
+			return nil, err
+		}
+		w.Active = active
+		if active {
+			w.ConsecutiveFailures, w.FirstConsecutiveFailDate = 0, nil
+		}
+	}
+	return w, nil
+}
+
+func validCallbackURL(callbackURL string) error {
+	u, err := url.Parse(callbackURL)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return errInvalid("value for callbackURL")
+	}
+	return nil
+}
+
+func (s *Server) routeWebhooks(r *http.Request, seg []string) (interface{}, error) {
+	if len(seg) == 0 {
+		if r.Method != http.MethodPost {
+			return nil, errCannot(r)
+		}
+		if err := validCallbackURL(r.Form.Get("callbackURL")); err != nil {
+			return nil, err
+		}
+		if !s.modelExists(r.Form.Get("idModel")) {
+			return nil, errInvalid("value for idModel")
+		}
+		for _, w := range s.webhooks {
+			if w.IDModel == r.Form.Get("idModel") && w.CallbackURL == r.Form.Get("callbackURL") {
+				return nil, errInvalid("A webhook with that callback, model, and token already exists")
+			}
+		}
+		w := &webhook{
+			ID:          s.newID(),
+			Description: r.Form.Get("description"),
+			IDModel:     r.Form.Get("idModel"),
+			CallbackURL: r.Form.Get("callbackURL"),
+			Active:      true,
+		}
+		if _, ok := r.Form["active"]; ok {
+			active, err := parseBool(r.Form.Get("active"), true)
+			if err != nil {
+				return nil, err
+			}
+			w.Active = active
+		}
+		s.webhooks[w.ID] = w
+		return w, nil
+	}
+	w, err := s.webhook(seg[0])
+	if err != nil {
+		return nil, err
+	}
+	if len(seg) == 1 {
+		switch r.Method {
+		case http.MethodGet:
+			return w, nil
+		case http.MethodPut:
+			return s.updateWebhook(w, r.Form)
+		case http.MethodDelete:
+			delete(s.webhooks, w.ID)
+			return map[string]interface{}{"_value": nil}, nil
+		}
+	}
+	return nil, errCannot(r)
+}
+
+// routeTokens - GET /tokens/{token}/webhooks (all webhooks belong to the one token)
+func (s *Server) routeTokens(r *http.Request, seg []string) (interface{}, error) {
+	if len(seg) == 2 && seg[1] == "webhooks" && r.Method == http.MethodGet {
+		if s.Token != "" && seg[0] != s.Token {
+			return nil, errInvalid("token")
+		}
+		webhooks := []*webhook{}
+		for _, w := range s.webhooks {
+			webhooks = append(webhooks, w)
+		}
+		sort.Slice(webhooks, func(i, j int) bool { return webhooks[i].ID < webhooks[j].ID })
+		return webhooks, nil
+	}
+	return nil, errCannot(r)
+}
+
+// DeactivateWebhook - Simulate Trello disabling a webhook after repeated callback failures
+func (s *Server) DeactivateWebhook(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w, ok := s.webhooks[id]
+	if ok {
+		date := s.date()
+		w.Active = false
+		w.ConsecutiveFailures = 1000
+		w.FirstConsecutiveFailDate = &date
+	}
+	return ok
+}
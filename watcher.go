@@ -0,0 +1,332 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trello
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// DefaultWatchInterval - Time between two polls of a Watcher
+const DefaultWatchInterval = 30 * time.Second
+
+// DefaultWatchMaxBackoff - Longest a Watcher waits between polls after repeated failures
+const DefaultWatchMaxBackoff = 5 * time.Minute
+
+// watchDedupSize - Number of recently emitted action IDs a Watcher remembers
+const watchDedupSize = 1000
+
+// CheckpointStore - Persists the ID of the last action a Watcher emitted
+// Keys are the actions resource being watched, e.g. "/boards/{id}/actions".
+type CheckpointStore interface {
+	// Load returns the saved action ID, or "" if there is none
+	Load(ctx context.Context, key string) (actionID string, err error)
+	Save(ctx context.Context, key, actionID string) error
+}
+
+// MemoryCheckpointStore - CheckpointStore kept in memory, lost when the process exits
+type MemoryCheckpointStore struct {
+	mu  sync.Mutex
+	ids map[string]string
+}
+
+// NewMemoryCheckpointStore - Create an empty MemoryCheckpointStore
+func NewMemoryCheckpointStore() *MemoryCheckpointStore {
+	return &MemoryCheckpointStore{ids: map[string]string{}}
+}
+
+// Load - Saved action ID for key
+func (s *MemoryCheckpointStore) Load(ctx context.Context, key string) (actionID string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.ids[key], nil
+}
+
+// Save - Save the action ID for key
+func (s *MemoryCheckpointStore) Save(ctx context.Context, key, actionID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ids[key] = actionID
+	return nil
+}
+
+// FileCheckpointStore - CheckpointStore keeping every key in one JSON file
+// The file is replaced atomically on each Save, and created if missing.
+type FileCheckpointStore struct {
+	Path string
+
+	mu sync.Mutex
+}
+
+// NewFileCheckpointStore - Create a FileCheckpointStore saving to path
+func NewFileCheckpointStore(path string) *FileCheckpointStore {
+	return &FileCheckpointStore{Path: path}
+}
+
+// Load - Saved action ID for key
+func (s *FileCheckpointStore) Load(ctx context.Context, key string) (actionID string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ids, err := s.read()
+	if err == nil {
+		actionID = ids[key]
+	}
+	return
+}
+
+// Save - Save the action ID for key
+func (s *FileCheckpointStore) Save(ctx context.Context, key, actionID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ids, err := s.read()
+	if err != nil {
+		return err
+	}
+	ids[key] = actionID
+	body, err := json.MarshalIndent(ids, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(s.Path), filepath.Base(s.Path)+".*")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(body)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), s.Path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+	return err
+}
+
+func (s *FileCheckpointStore) read() (ids map[string]string, err error) {
+	ids = map[string]string{}
+	body, err := ioutil.ReadFile(s.Path)
+	if os.IsNotExist(err) {
+		return ids, nil
+	}
+	if err == nil && len(body) > 0 {
+		err = json.Unmarshal(body, &ids)
+	}
+	return
+}
+
+// Watcher - Polls the actions of a board, member or organization and emits new ones, oldest first
+// The ID of the last action emitted is saved in Checkpoints so a restarted Watcher resumes
+// where it stopped. Delivery is at least once: an action whose handler returned just before
+// the checkpoint was saved is emitted again. Recently emitted IDs are remembered to skip those
+// repeats within a process.
+type Watcher struct {
+	// Interval - Time between polls (DefaultWatchInterval if zero)
+	Interval time.Duration
+	// MaxBackoff - Upper bound of the delay, doubled after each failed poll (DefaultWatchMaxBackoff if zero)
+	MaxBackoff time.Duration
+	// Types - Action types emitted (all types if empty)
+	Types []ActionType
+	// Since - Where to start when there is no checkpoint; if zero only actions newer than the first poll are emitted
+	Since time.Time
+	// Checkpoints - Where the last emitted action ID is saved (a MemoryCheckpointStore by default)
+	Checkpoints CheckpointStore
+
+	client   *Client
+	resource string
+
+	mu     sync.Mutex
+	seen   map[string]bool
+	recent []string
+}
+
+// Watcher - Create a Watcher for the actions of a Board
+// - https://developer.atlassian.com/cloud/trello/rest/api-group-boards/#api-boards-boardid-actions-get
+func (b *Board) Watcher() *Watcher {
+	return newWatcher(b.client, "/boards/"+b.ID+"/actions")
+}
+
+// Watcher - Create a Watcher for the actions of a Member
+// - https://developer.atlassian.com/cloud/trello/rest/api-group-members/#api-members-id-actions-get
+func (m *Member) Watcher() *Watcher {
+	return newWatcher(m.client, "/members/"+m.ID+"/actions")
+}
+
+// Watcher - Create a Watcher for the actions of an Organization
+// - https://developer.atlassian.com/cloud/trello/rest/api-group-organizations/#api-organizations-id-actions-get
+func (o *Organization) Watcher() *Watcher {
+	return newWatcher(o.client, "/organizations/"+o.ID+"/actions")
+}
+
+func newWatcher(client *Client, resource string) *Watcher {
+	return &Watcher{
+		Interval:    DefaultWatchInterval,
+		MaxBackoff:  DefaultWatchMaxBackoff,
+		Checkpoints: NewMemoryCheckpointStore(),
+		client:      client,
+		resource:    resource,
+		seen:        map[string]bool{},
+	}
+}
+
+// Key - Key of the checkpoint of this Watcher in its CheckpointStore
+func (w *Watcher) Key() string {
+	return w.resource
+}
+
+// Run - Poll until ctx is done, calling handler for each new action
+// Failed polls are logged through the client Logger and retried with exponential backoff.
+// Pass Dispatcher.Dispatch as handler to route the actions by type. Run returns ctx.Err().
+func (w *Watcher) Run(ctx context.Context, handler ActionHandler) error {
+	failures := 0
+	for {
+		delay := w.interval()
+		if err := w.Poll(ctx, handler); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			failures++
+			delay = w.backoff(failures)
+			w.client.logf("trello: watching %s failed (%d in a row), next poll in %v: %v", w.resource, failures, delay, err)
+		} else {
+			failures = 0
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Watch - Run the Watcher in a goroutine, sending new actions on the returned channel
+// The channel is closed once ctx is done. An action counts as emitted once it was received.
+func (w *Watcher) Watch(ctx context.Context) <-chan Action {
+	actions := make(chan Action)
+	go func() {
+		defer close(actions)
+		w.Run(ctx, func(ctx context.Context, action *Action) {
+			select {
+			case actions <- *action:
+			case <-ctx.Done():
+			}
+		})
+	}()
+	return actions
+}
+
+// Poll - Fetch the actions since the checkpoint once, calling handler for each, oldest first
+// The checkpoint is saved after each action handled.
+func (w *Watcher) Poll(ctx context.Context, handler ActionHandler) (err error) {
+	last, err := w.Checkpoints.Load(ctx, w.resource)
+	if err != nil {
+		return
+	}
+	filter := ActionFilter{Types: w.Types}
+	switch {
+	case last != "":
+		filter.SinceID = last
+	case !w.Since.IsZero():
+		filter.Since = w.Since
+	default:
+		return w.start(ctx)
+	}
+
+	var actions []Action
+	for action, err := range w.client.actionsIter(ctx, w.resource, filter) {
+		if err != nil {
+			return err
+		}
+		actions = append(actions, action)
+	}
+	for i := len(actions) - 1; i >= 0; i-- {
+		action := &actions[i]
+		// An action emitted before its checkpoint failed to save is only saved now
+		if !w.emitted(action.ID) {
+			handler(ctx, action)
+			if err = ctx.Err(); err != nil {
+				return
+			}
+			w.remember(action.ID)
+		}
+		if err = w.Checkpoints.Save(ctx, w.resource, action.ID); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// start - Save the newest action as the checkpoint, without emitting it
+func (w *Watcher) start(ctx context.Context) (err error) {
+	for action, err := range w.client.actionsIter(ctx, w.resource, ActionFilter{Types: w.Types, PageSize: 1}) {
+		if err != nil {
+			return err
+		}
+		return w.Checkpoints.Save(ctx, w.resource, action.ID)
+	}
+	// No action yet: start from the beginning
+	return w.Checkpoints.Save(ctx, w.resource, "000000000000000000000000")
+}
+
+func (w *Watcher) emitted(actionID string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.seen[actionID]
+}
+
+func (w *Watcher) remember(actionID string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.recent) == watchDedupSize {
+		delete(w.seen, w.recent[0])
+		w.recent = w.recent[1:]
+	}
+	w.seen[actionID] = true
+	w.recent = append(w.recent, actionID)
+}
+
+func (w *Watcher) interval() time.Duration {
+	if w.Interval <= 0 {
+		return DefaultWatchInterval
+	}
+	return w.Interval
+}
+
+// backoff - Interval doubled for each consecutive failure, up to MaxBackoff
+func (w *Watcher) backoff(failures int) time.Duration {
+	max := w.MaxBackoff
+	if max <= 0 {
+		max = DefaultWatchMaxBackoff
+	}
+	d := w.interval()
+	for i := 0; i < failures && d < max; i++ {
+		d *= 2
+	}
+	if d > max {
+		d = max
+	}
+	return d
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trello
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	goblin "github.com/franela/goblin"
+	. "github.com/onsi/gomega"
+)
+
+// failingCheckpointStore - MemoryCheckpointStore whose Save fails while failing is set
+type failingCheckpointStore struct {
+	*MemoryCheckpointStore
+	failing bool
+}
+
+func (s *failingCheckpointStore) Save(ctx context.Context, key, actionID string) error {
+	if s.failing {
+		return errors.New("checkpoint store unavailable")
+	}
+	return s.MemoryCheckpointStore.Save(ctx, key, actionID)
+}
+
+func TestWatcher(t *testing.T) {
+	g := goblin.Goblin(t)
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+
+	g.Describe("Watcher tests", func() {
+		var board *Board
+		var list *List
+		var watcher *Watcher
+		var handled []Action
+		ctx := context.Background()
+
+		handler := func(ctx context.Context, action *Action) {
+			handled = append(handled, *action)
+		}
+
+		g.Before(func() {
+			board, err = client.CreateBoard(testName("GoTestTrello-Watcher"))
+			Expect(err).To(BeNil())
+			lists, err := board.Lists()
+			Expect(err).To(BeNil())
+			list = &lists[0]
+		})
+
+		g.BeforeEach(func() {
+			watcher = board.Watcher()
+			handled = nil
+		})
+
+		g.It("should only emit actions newer than the first poll", func() {
+			Expect(watcher.Poll(ctx, handler)).To(BeNil())
+			Expect(handled).To(BeEmpty())
+
+			card, err := list.AddCard(Card{Name: "Watched"})
+			Expect(err).To(BeNil())
+			_, err = card.AddComment("Watched comment")
+			Expect(err).To(BeNil())
+			Expect(watcher.Poll(ctx, handler)).To(BeNil())
+			Expect(len(handled)).To(Equal(2))
+			Expect(handled[0].Type).To(Equal(CreateCard))
+			Expect(handled[1].Type).To(Equal(CommentCard))
+			Expect(handled[1].client).To(Equal(client))
+
+			Expect(watcher.Poll(ctx, handler)).To(BeNil())
+			Expect(len(handled)).To(Equal(2))
+			last, err := watcher.Checkpoints.Load(ctx, watcher.Key())
+			Expect(err).To(BeNil())
+			Expect(last).To(Equal(handled[1].ID))
+		})
+
+		g.It("should start from Since without a checkpoint", func() {
+			// Derived from the board ID rather than the clock, so the query replays from a cassette
+			created, err := IDTime(board.ID)
+			Expect(err).To(BeNil())
+			watcher.Since = created.Add(-24 * time.Hour)
+			watcher.Types = []ActionType{CreateCard}
+			Expect(watcher.Poll(ctx, handler)).To(BeNil())
+			Expect(len(handled)).To(Equal(1))
+			Expect(handled[0].Data.Card.Name).To(Equal("Watched"))
+		})
+
+		g.It("should resume from a saved checkpoint", func() {
+			store := NewMemoryCheckpointStore()
+			watcher.Checkpoints = store
+			Expect(watcher.Poll(ctx, handler)).To(BeNil())
+			_, err := list.AddCard(Card{Name: "While stopped"})
+			Expect(err).To(BeNil())
+
+			restarted := board.Watcher()
+			restarted.Checkpoints = store
+			Expect(restarted.Poll(ctx, handler)).To(BeNil())
+			Expect(len(handled)).To(Equal(1))
+			Expect(handled[0].Data.Card.Name).To(Equal("While stopped"))
+		})
+
+		g.It("should not emit an action twice when saving the checkpoint fails", func() {
+			store := &failingCheckpointStore{MemoryCheckpointStore: NewMemoryCheckpointStore()}
+			watcher.Checkpoints = store
+			Expect(watcher.Poll(ctx, handler)).To(BeNil())
+			_, err := list.AddCard(Card{Name: "Unsaved"})
+			Expect(err).To(BeNil())
+
+			store.failing = true
+			Expect(watcher.Poll(ctx, handler)).NotTo(BeNil())
+			store.failing = false
+			Expect(watcher.Poll(ctx, handler)).To(BeNil())
+			Expect(len(handled)).To(Equal(1))
+			last, err := store.Load(ctx, watcher.Key())
+			Expect(err).To(BeNil())
+			Expect(last).To(Equal(handled[0].ID))
+
+			// A restarted watcher does not know the action was emitted
+			restarted := board.Watcher()
+			restarted.Checkpoints = store
+			Expect(restarted.Poll(ctx, handler)).To(BeNil())
+			Expect(len(handled)).To(Equal(1))
+		})
+
+		g.It("should persist checkpoints in a file", func() {
+			dir, err := ioutil.TempDir("", "trello-watcher")
+			Expect(err).To(BeNil())
+			defer os.RemoveAll(dir)
+			path := filepath.Join(dir, "checkpoints.json")
+
+			store := NewFileCheckpointStore(path)
+			last, err := store.Load(ctx, "/boards/1/actions")
+			Expect(err).To(BeNil())
+			Expect(last).To(BeEmpty())
+			Expect(store.Save(ctx, "/boards/1/actions", "5f0000000000000000000001")).To(BeNil())
+			Expect(store.Save(ctx, "/boards/2/actions", "5f0000000000000000000002")).To(BeNil())
+
+			last, err = NewFileCheckpointStore(path).Load(ctx, "/boards/1/actions")
+			Expect(err).To(BeNil())
+			Expect(last).To(Equal("5f0000000000000000000001"))
+			files, err := ioutil.ReadDir(dir)
+			Expect(err).To(BeNil())
+			Expect(len(files)).To(Equal(1))
+		})
+
+		g.It("should send actions on a channel and dispatch them", func() {
+			watcher.Interval = 10 * time.Millisecond
+			Expect(watcher.Poll(ctx, handler)).To(BeNil())
+			_, err := list.AddCard(Card{Name: "Channel"})
+			Expect(err).To(BeNil())
+
+			watchCtx, cancel := context.WithCancel(ctx)
+			actions := watcher.Watch(watchCtx)
+			select {
+			case action := <-actions:
+				Expect(action.Type).To(Equal(CreateCard))
+				Expect(action.Data.Card.Name).To(Equal("Channel"))
+			case <-time.After(10 * time.Second):
+				g.Fail("no action received")
+			}
+			cancel()
+			for range actions {
+			}
+
+			dispatcher := NewDispatcher()
+			dispatcher.OnCreateCard(handler)
+			_, err = list.AddCard(Card{Name: "Dispatched"})
+			Expect(err).To(BeNil())
+			Expect(watcher.Poll(ctx, dispatcher.Dispatch)).To(BeNil())
+			Expect(len(handled)).To(Equal(1))
+			Expect(handled[0].Data.Card.Name).To(Equal("Dispatched"))
+		})
+
+		g.It("should back off after failed polls", func() {
+			watcher.Interval = time.Second
+			watcher.MaxBackoff = 5 * time.Second
+			Expect(watcher.backoff(1)).To(Equal(2 * time.Second))
+			Expect(watcher.backoff(2)).To(Equal(4 * time.Second))
+			Expect(watcher.backoff(3)).To(Equal(5 * time.Second))
+
+			runCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+			defer cancel()
+			broken := unloadedBoard(client, "000000000000000000000000").Watcher()
+			Expect(broken.Run(runCtx, handler)).To(Equal(context.DeadlineExceeded))
+			Expect(handled).To(BeEmpty())
+		})
+
+		g.After(func() {
+			err = board.Delete()
+			Expect(err).To(BeNil())
+		})
+	})
+}
@@ -1,10 +1,12 @@
 package trello
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/url"
 	"strconv"
+	"time"
 )
 
 // Webhook - Trello Webhook Type
@@ -15,15 +17,23 @@ type Webhook struct {
 	IDModel     string `json:"idModel"`
 	CallbackURL string `json:"callbackURL"`
 	Active      bool   `json:"active"`
+	// ConsecutiveFailures - Failed deliveries in a row; Trello deactivates webhooks that keep failing
+	ConsecutiveFailures      int        `json:"consecutiveFailures"`
+	FirstConsecutiveFailDate *time.Time `json:"firstConsecutiveFailDate"`
 }
 
 // Webhooks - Get Webhooks for a token (string)
 // https://developer.atlassian.com/cloud/trello/rest/api-group-tokens/#api-tokens-token-webhooks-get
 func (c *Client) Webhooks(token string) (webhooks []Webhook, err error) {
+	return c.WebhooksContext(context.Background(), token)
+}
+
+// WebhooksContext - Get Webhooks for a token (with context)
+func (c *Client) WebhooksContext(ctx context.Context, token string) (webhooks []Webhook, err error) {
 
-	body, err := c.Get(webhookURL(token))
+	body, err := c.GetContext(ctx, webhookURL(token))
 	if err == nil {
-		err = json.Unmarshal(body, &webhooks)
+		webhooks, err = parseListWebhooks(body, c)
 	}
 	return
 }
@@ -31,12 +41,17 @@ func (c *Client) Webhooks(token string) (webhooks []Webhook, err error) {
 // CreateWebhook - Create a Webhook
 // - https://developer.atlassian.com/cloud/trello/rest/api-group-webhooks/#api-webhooks-post
 func (c *Client) CreateWebhook(hook Webhook) (webhook *Webhook, err error) {
+	return c.CreateWebhookContext(context.Background(), hook)
+}
+
+// CreateWebhookContext - Create a Webhook (with context)
+func (c *Client) CreateWebhookContext(ctx context.Context, hook Webhook) (webhook *Webhook, err error) {
 	webhook = &Webhook{}
 	payload := url.Values{}
 	payload.Set("description", hook.Description)
 	payload.Set("callbackURL", hook.CallbackURL)
 	payload.Set("idModel", hook.IDModel)
-	body, err := c.Post("/webhooks/", payload)
+	body, err := c.PostContext(ctx, "/webhooks/", payload)
 	if err == nil {
 		err = json.Unmarshal(body, &webhook)
 		webhook.client = c
@@ -47,9 +62,14 @@ func (c *Client) CreateWebhook(hook Webhook) (webhook *Webhook, err error) {
 // Webhook - Get Webhook by id (string)
 // - https://developer.atlassian.com/cloud/trello/rest/api-group-webhooks/#api-webhooks-id-get
 func (c *Client) Webhook(webhookID string) (webhook *Webhook, err error) {
+	return c.WebhookContext(context.Background(), webhookID)
+}
+
+// WebhookContext - Get Webhook by id (with context)
+func (c *Client) WebhookContext(ctx context.Context, webhookID string) (webhook *Webhook, err error) {
 	webhook = &Webhook{}
 	url := fmt.Sprintf("/webhooks/%s/", webhookID)
-	body, err := c.Get(url)
+	body, err := c.GetContext(ctx, url)
 	if err == nil {
 		err = parseWebhook(body, webhook, c)
 	}
@@ -59,36 +79,56 @@ func (c *Client) Webhook(webhookID string) (webhook *Webhook, err error) {
 // SetActive - Set Active for WebHook
 // true - active, false - inactive
 func (w *Webhook) SetActive(active bool) (err error) {
+	return w.SetActiveContext(context.Background(), active)
+}
+
+// SetActiveContext - Set Active for WebHook (with context)
+func (w *Webhook) SetActiveContext(ctx context.Context, active bool) (err error) {
 	payload := url.Values{}
 	payload.Set("active", strconv.FormatBool(active))
-	return w.update(payload)
+	return w.update(ctx, payload)
 }
 
 // SetCallbackURL - Set SetCallbackURL for WebHook
 func (w *Webhook) SetCallbackURL(callbackURL string) (err error) {
+	return w.SetCallbackURLContext(context.Background(), callbackURL)
+}
+
+// SetCallbackURLContext - Set SetCallbackURL for WebHook (with context)
+func (w *Webhook) SetCallbackURLContext(ctx context.Context, callbackURL string) (err error) {
 	payload := url.Values{}
 	payload.Set("callbackURL", callbackURL)
-	return w.update(payload)
+	return w.update(ctx, payload)
 }
 
 // SetDescription - Set Description for WebHook
 func (w *Webhook) SetDescription(description string) (err error) {
+	return w.SetDescriptionContext(context.Background(), description)
+}
+
+// SetDescriptionContext - Set Description for WebHook (with context)
+func (w *Webhook) SetDescriptionContext(ctx context.Context, description string) (err error) {
 	payload := url.Values{}
 	payload.Set("description", description)
-	return w.update(payload)
+	return w.update(ctx, payload)
 }
 
 // SetIDModel - Set IDModel for WebHook
 func (w *Webhook) SetIDModel(model string) (err error) {
+	return w.SetIDModelContext(context.Background(), model)
+}
+
+// SetIDModelContext - Set IDModel for WebHook (with context)
+func (w *Webhook) SetIDModelContext(ctx context.Context, model string) (err error) {
 	payload := url.Values{}
 	payload.Set("idModel", model)
-	return w.update(payload)
+	return w.update(ctx, payload)
 }
 
 // update - Update a Webhook (with payload)
 // - https://developer.atlassian.com/cloud/trello/rest/api-group-webhooks/#api-webhooks-id-put
-func (w *Webhook) update(payload url.Values) (err error) {
-	body, err := w.client.Put("/webhooks/"+w.ID, payload)
+func (w *Webhook) update(ctx context.Context, payload url.Values) (err error) {
+	body, err := w.client.PutContext(ctx, "/webhooks/"+w.ID, payload)
 	if err == nil {
 		err = parseWebhook(body, w, w.client)
 	}
@@ -98,15 +138,25 @@ func (w *Webhook) update(payload url.Values) (err error) {
 // Delete - Delete a Webhook (string)
 // - https://developer.atlassian.com/cloud/trello/rest/api-group-webhooks/#api-webhooks-id-delete
 func (w *Webhook) Delete() (err error) {
-	return w.client.DeleteWebhook(w.ID)
+	return w.DeleteContext(context.Background())
+}
+
+// DeleteContext - Delete a Webhook (with context)
+func (w *Webhook) DeleteContext(ctx context.Context) (err error) {
+	return w.client.DeleteWebhookContext(ctx, w.ID)
 }
 
 // DeleteWebhook - Delete a Webhook by id (string)
 // - https://developer.atlassian.com/cloud/trello/rest/api-group-webhooks/#api-webhooks-id-delete
 func (c *Client) DeleteWebhook(webhookID string) (err error) {
+	return c.DeleteWebhookContext(context.Background(), webhookID)
+}
+
+// DeleteWebhookContext - Delete a Webhook by id (with context)
+func (c *Client) DeleteWebhookContext(ctx context.Context, webhookID string) (err error) {
 
 	url := fmt.Sprintf("/webhooks/%s/", webhookID)
-	_, err = c.Delete(url)
+	_, err = c.DeleteContext(ctx, url)
 	return
 }
 
@@ -123,10 +173,10 @@ func parseWebhook(body []byte, webhook *Webhook, client *Client) (err error) {
 	return
 }
 
-// func parseListWebhooks(body []byte, client *Client) (webhooks []Webhook, err error) {
-// 	err = json.Unmarshal(body, &webhooks)
-// 	for i := range webhooks {
-// 		webhooks[i].client = client
-// 	}
-// 	return
-// }
+func parseListWebhooks(body []byte, client *Client) (webhooks []Webhook, err error) {
+	err = json.Unmarshal(body, &webhooks)
+	for i := range webhooks {
+		webhooks[i].client = client
+	}
+	return
+}
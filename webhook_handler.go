@@ -0,0 +1,278 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trello
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// WebhookSignatureHeader - Header carrying the signature of a webhook request
+const WebhookSignatureHeader = "X-Trello-Webhook"
+
+// DefaultReplayWindow - How long delivered action IDs are remembered, and the
+// maximum age of an action accepted by a WebhookHandler
+const DefaultReplayWindow = 10 * time.Minute
+
+// maxWebhookBody - Largest webhook request body accepted
+const maxWebhookBody = 4 << 20
+
+// WebhookEvent - Payload Trello sends to a webhook callback URL
+// Model is the raw model the webhook watches; the matching typed field
+// (Board, Card, List, Member or Organization) is also set.
+type WebhookEvent struct {
+	Action  Action          `json:"action"`
+	Model   json.RawMessage `json:"model"`
+	Webhook Webhook         `json:"webhook"`
+
+	Board        *Board        `json:"-"`
+	Card         *Card         `json:"-"`
+	List         *List         `json:"-"`
+	Member       *Member       `json:"-"`
+	Organization *Organization `json:"-"`
+}
+
+// WebhookCallback - Function called for each webhook event
+// Returning an error responds with a 500 so Trello delivers the event again.
+type WebhookCallback func(ctx context.Context, event *WebhookEvent) error
+
+// WebhookHandler - http.Handler receiving Trello webhook requests
+// - https://developer.atlassian.com/cloud/trello/guides/rest-api/webhooks/
+type WebhookHandler struct {
+	// ReplayWindow - How long action IDs are remembered and how old an action may be
+	ReplayWindow time.Duration
+
+	secret      string
+	callbackURL string
+	client      *Client
+	now         func() time.Time
+
+	mu        sync.Mutex
+	callbacks []WebhookCallback
+	seen      map[string]time.Time
+	inFlight  map[string]bool
+}
+
+// NewWebhookHandler - Create a handler for webhooks registered with callbackURL
+// secret is the application secret shown at https://trello.com/app-key and
+// callbackURL must match the URL the webhook was created with exactly.
+func NewWebhookHandler(secret, callbackURL string) *WebhookHandler {
+	return &WebhookHandler{
+		ReplayWindow: DefaultReplayWindow,
+		secret:       secret,
+		callbackURL:  callbackURL,
+		now:          time.Now,
+		seen:         map[string]time.Time{},
+		inFlight:     map[string]bool{},
+	}
+}
+
+// SetClient - Client set on the decoded action and model, so their methods can be called
+func (h *WebhookHandler) SetClient(client *Client) {
+	h.client = client
+}
+
+// Handle - Register a callback for every verified event
+func (h *WebhookHandler) Handle(callback WebhookCallback) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.callbacks = append(h.callbacks, callback)
+}
+
+// ServeHTTP - Answer the HEAD verification probe and dispatch signed POST requests
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodHead:
+		w.WriteHeader(http.StatusOK)
+		return
+	case http.MethodPost:
+	default:
+		w.Header().Set("Allow", "HEAD, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBody))
+	if err != nil {
+		http.Error(w, "unable to read body", http.StatusBadRequest)
+		return
+	}
+	if !h.Verify(body, r.Header.Get(WebhookSignatureHeader)) {
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+	event, err := h.parseEvent(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	now := h.now()
+	if !event.Action.Date.IsZero() && now.Sub(event.Action.Date) > h.ReplayWindow {
+		http.Error(w, "action is too old", http.StatusBadRequest)
+		return
+	}
+	switch h.claim(event.Action.ID, now) {
+	case claimDelivered:
+		// Already delivered: acknowledge without dispatching again
+		w.WriteHeader(http.StatusOK)
+		return
+	case claimInFlight:
+		// Another delivery is being handled and may still fail: have Trello retry this one
+		http.Error(w, "action is being handled", http.StatusConflict)
+		return
+	}
+
+	h.mu.Lock()
+	callbacks := append([]WebhookCallback(nil), h.callbacks...)
+	h.mu.Unlock()
+	for _, callback := range callbacks {
+		if err = callback(r.Context(), event); err != nil {
+			h.done(event.Action.ID, h.now(), false)
+			http.Error(w, "unable to handle event", http.StatusInternalServerError)
+			return
+		}
+	}
+	h.done(event.Action.ID, h.now(), true)
+	w.WriteHeader(http.StatusOK)
+}
+
+// Verify - Check the signature of a webhook request body
+func (h *WebhookHandler) Verify(body []byte, signature string) bool {
+	got, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil || signature == "" {
+		return false
+	}
+	return hmac.Equal(got, webhookMAC(h.secret, h.callbackURL, body))
+}
+
+// SignWebhook - Signature Trello sends for a webhook request body (useful in tests)
+func SignWebhook(secret, callbackURL string, body []byte) string {
+	return base64.StdEncoding.EncodeToString(webhookMAC(secret, callbackURL, body))
+}
+
+func webhookMAC(secret, callbackURL string, body []byte) []byte {
+	mac := hmac.New(sha1.New, []byte(secret))
+	mac.Write(body)
+	mac.Write([]byte(callbackURL))
+	return mac.Sum(nil)
+}
+
+// claimResult - Outcome of claiming an action ID
+type claimResult int
+
+const (
+	claimed claimResult = iota
+	claimDelivered
+	claimInFlight
+)
+
+// claim - Mark an action ID as being handled, unless it was already delivered (within
+// the window) or another delivery of it is still being handled
+func (h *WebhookHandler) claim(actionID string, now time.Time) claimResult {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for id, at := range h.seen {
+		if now.Sub(at) > h.ReplayWindow {
+			delete(h.seen, id)
+		}
+	}
+	if _, ok := h.seen[actionID]; ok {
+		return claimDelivered
+	}
+	if h.inFlight[actionID] {
+		return claimInFlight
+	}
+	h.inFlight[actionID] = true
+	return claimed
+}
+
+// done - Finish handling a claimed action ID, recording it as delivered if every callback succeeded
+func (h *WebhookHandler) done(actionID string, now time.Time, delivered bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.inFlight, actionID)
+	if delivered {
+		h.seen[actionID] = now
+	}
+}
+
+// parseEvent - Decode a webhook payload, including the typed model
+func (h *WebhookHandler) parseEvent(body []byte) (event *WebhookEvent, err error) {
+	event = &WebhookEvent{}
+	if err = json.Unmarshal(body, event); err != nil {
+		return nil, fmt.Errorf("Unable to parse webhook payload: %v", err)
+	}
+	if event.Action.ID == "" {
+		return nil, fmt.Errorf("Webhook payload has no action")
+	}
+	event.Action.client = h.client
+	event.Webhook.client = h.client
+	if len(event.Model) > 0 {
+		err = event.parseModel(h.client)
+	}
+	return
+}
+
+// parseModel - Decode the model into the struct matching its shape
+// Members and organizations also carry prefs, so they are told apart first; labels and
+// checklists (which carry idBoard too) are only available as the raw model.
+func (e *WebhookEvent) parseModel(client *Client) (err error) {
+	var shape struct {
+		IDList      string          `json:"idList"`
+		IDBoard     string          `json:"idBoard"`
+		IDCard      string          `json:"idCard"`
+		Pos         json.RawMessage `json:"pos"`
+		Closed      json.RawMessage `json:"closed"`
+		Prefs       json.RawMessage `json:"prefs"`
+		Username    string          `json:"username"`
+		DisplayName string          `json:"displayName"`
+	}
+	if err = json.Unmarshal(e.Model, &shape); err != nil {
+		return fmt.Errorf("Unable to parse webhook model: %v", err)
+	}
+	switch {
+	case shape.Username != "":
+		e.Member = &Member{}
+		err = parseMember(e.Model, e.Member, client)
+	case shape.DisplayName != "":
+		e.Organization = &Organization{}
+		err = parseOrganization(e.Model, e.Organization, client)
+	case shape.IDList != "":
+		e.Card = &Card{}
+		err = parseCard(e.Model, e.Card, client)
+	case shape.IDCard != "":
+		// Checklists are only available as the raw model
+	case shape.IDBoard != "" && shape.Pos != nil && shape.Closed != nil:
+		e.List = &List{}
+		err = parseList(e.Model, e.List, client)
+	case shape.IDBoard != "":
+		// Labels are only available as the raw model
+	case shape.Prefs != nil:
+		e.Board = &Board{}
+		err = e.Board.parseBoard(e.Model, client)
+	}
+	return
+}
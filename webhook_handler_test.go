@@ -0,0 +1,236 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trello
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	goblin "github.com/franela/goblin"
+	. "github.com/onsi/gomega"
+)
+
+const (
+	testWebhookSecret   = "webhook-secret"
+	testWebhookCallback = "https://example.com/trello/webhook"
+)
+
+// webhookPayload - A webhook request body for an action on a card
+func webhookPayload(actionID string, date time.Time) string {
+	return fmt.Sprintf(`{
+		"action": {
+			"id": %q,
+			"idMemberCreator": "5f0000000000000000000001",
+			"type": "updateCard",
+			"date": %q,
+			"data": {"card": {"id": "5f0000000000000000000002", "name": "Card"}, "list": {"id": "5f0000000000000000000003", "name": "List"}}
+		},
+		"model": {"id": "5f0000000000000000000002", "name": "Card", "idList": "5f0000000000000000000003", "idBoard": "5f0000000000000000000004"},
+		"webhook": {"id": "5f0000000000000000000005", "idModel": "5f0000000000000000000002", "callbackURL": %q, "active": true}
+	}`, actionID, date.UTC().Format(time.RFC3339), testWebhookCallback)
+}
+
+func webhookRequest(method, body, signature string) *http.Request {
+	req := httptest.NewRequest(method, testWebhookCallback, strings.NewReader(body))
+	if signature != "" {
+		req.Header.Set(WebhookSignatureHeader, signature)
+	}
+	return req
+}
+
+func TestWebhookHandler(t *testing.T) {
+	g := goblin.Goblin(t)
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+
+	g.Describe("Webhook handler tests", func() {
+		var handler *WebhookHandler
+		var events []*WebhookEvent
+		var failures int
+
+		serve := func(req *http.Request) int {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			return rec.Code
+		}
+
+		signed := func(body string) *http.Request {
+			return webhookRequest(http.MethodPost, body, SignWebhook(testWebhookSecret, testWebhookCallback, []byte(body)))
+		}
+
+		g.BeforeEach(func() {
+			events = nil
+			failures = 0
+			handler = NewWebhookHandler(testWebhookSecret, testWebhookCallback)
+			handler.SetClient(client)
+			handler.Handle(func(ctx context.Context, event *WebhookEvent) error {
+				if failures > 0 {
+					failures--
+					return errors.New("temporary failure")
+				}
+				events = append(events, event)
+				return nil
+			})
+		})
+
+		g.It("should answer the verification probe", func() {
+			Expect(serve(webhookRequest(http.MethodHead, "", ""))).To(Equal(http.StatusOK))
+			Expect(events).To(BeEmpty())
+		})
+
+		g.It("should reject other methods", func() {
+			Expect(serve(webhookRequest(http.MethodGet, "", ""))).To(Equal(http.StatusMethodNotAllowed))
+		})
+
+		g.It("should dispatch a signed event with the decoded action and model", func() {
+			Expect(serve(signed(webhookPayload("5f00000000000000000000a1", time.Now())))).To(Equal(http.StatusOK))
+			Expect(len(events)).To(Equal(1))
+			event := events[0]
+			Expect(event.Action.ID).To(Equal("5f00000000000000000000a1"))
+			Expect(event.Action.Type).To(Equal(UpdateCard))
+			Expect(event.Action.Data.Card.Name).To(Equal("Card"))
+			Expect(event.Action.client).To(Equal(client))
+			Expect(event.Webhook.IDModel).To(Equal("5f0000000000000000000002"))
+			Expect(event.Card).NotTo(BeNil())
+			Expect(event.Card.IDList).To(Equal("5f0000000000000000000003"))
+			Expect(event.Card.client).To(Equal(client))
+			Expect(event.Board).To(BeNil())
+			Expect(event.List).To(BeNil())
+		})
+
+		g.It("should decode board models", func() {
+			body := `{"action": {"id": "5f00000000000000000000a2", "type": "updateBoard", "date": "` +
+				time.Now().UTC().Format(time.RFC3339) + `"}, "model": {"id": "5f0000000000000000000004", "name": "Board", "prefs": {"background": "blue"}}}`
+			Expect(serve(signed(body))).To(Equal(http.StatusOK))
+			Expect(events[0].Board).NotTo(BeNil())
+			Expect(events[0].Board.Prefs.Background).To(Equal("blue"))
+		})
+
+		g.It("should decode member, organization, list and label models", func() {
+			model := func(id, model string) string {
+				return `{"action": {"id": "` + id + `", "type": "updateBoard", "date": "` +
+					time.Now().UTC().Format(time.RFC3339) + `"}, "model": ` + model + `}`
+			}
+			Expect(serve(signed(model("5f00000000000000000000b1",
+				`{"id": "5f0000000000000000000006", "username": "tester", "fullName": "Test User", "prefs": {"colorBlind": false}}`)))).To(Equal(http.StatusOK))
+			Expect(serve(signed(model("5f00000000000000000000b2",
+				`{"id": "5f0000000000000000000007", "name": "team", "displayName": "Team", "prefs": {"permissionLevel": "private"}}`)))).To(Equal(http.StatusOK))
+			Expect(serve(signed(model("5f00000000000000000000b3",
+				`{"id": "5f0000000000000000000003", "name": "List", "idBoard": "5f0000000000000000000004", "pos": 16384, "closed": false}`)))).To(Equal(http.StatusOK))
+			Expect(serve(signed(model("5f00000000000000000000b4",
+				`{"id": "5f0000000000000000000008", "name": "Bug", "color": "red", "idBoard": "5f0000000000000000000004"}`)))).To(Equal(http.StatusOK))
+			Expect(len(events)).To(Equal(4))
+
+			Expect(events[0].Member).NotTo(BeNil())
+			Expect(events[0].Member.Username).To(Equal("tester"))
+			Expect(events[0].Board).To(BeNil())
+			Expect(events[1].Organization).NotTo(BeNil())
+			Expect(events[1].Organization.DisplayName).To(Equal("Team"))
+			Expect(events[1].Board).To(BeNil())
+			Expect(events[2].List).NotTo(BeNil())
+			Expect(events[2].List.IDBoard).To(Equal("5f0000000000000000000004"))
+			Expect(events[3].List).To(BeNil())
+			Expect(events[3].Board).To(BeNil())
+			Expect(string(events[3].Model)).To(ContainSubstring(`"color": "red"`))
+		})
+
+		g.It("should reject forged requests", func() {
+			body := webhookPayload("5f00000000000000000000a3", time.Now())
+			Expect(serve(webhookRequest(http.MethodPost, body, ""))).To(Equal(http.StatusUnauthorized))
+			Expect(serve(webhookRequest(http.MethodPost, body, "not base64!"))).To(Equal(http.StatusUnauthorized))
+			forged := SignWebhook("another-secret", testWebhookCallback, []byte(body))
+			Expect(serve(webhookRequest(http.MethodPost, body, forged))).To(Equal(http.StatusUnauthorized))
+			// Signed for another callback URL
+			other := SignWebhook(testWebhookSecret, "https://example.com/other", []byte(body))
+			Expect(serve(webhookRequest(http.MethodPost, body, other))).To(Equal(http.StatusUnauthorized))
+			// Body changed after signing
+			signature := SignWebhook(testWebhookSecret, testWebhookCallback, []byte(body))
+			tampered := strings.Replace(body, "updateCard", "deleteCard", 1)
+			Expect(serve(webhookRequest(http.MethodPost, tampered, signature))).To(Equal(http.StatusUnauthorized))
+			Expect(events).To(BeEmpty())
+		})
+
+		g.It("should not dispatch replayed requests twice", func() {
+			body := webhookPayload("5f00000000000000000000a4", time.Now())
+			Expect(serve(signed(body))).To(Equal(http.StatusOK))
+			Expect(serve(signed(body))).To(Equal(http.StatusOK))
+			Expect(len(events)).To(Equal(1))
+		})
+
+		g.It("should reject actions older than the replay window", func() {
+			body := webhookPayload("5f00000000000000000000a5", time.Now().Add(-time.Hour))
+			Expect(serve(signed(body))).To(Equal(http.StatusBadRequest))
+			Expect(events).To(BeEmpty())
+
+			handler.ReplayWindow = 2 * time.Hour
+			Expect(serve(signed(body))).To(Equal(http.StatusOK))
+			Expect(len(events)).To(Equal(1))
+		})
+
+		g.It("should forget delivered actions after the replay window", func() {
+			start := time.Now()
+			Expect(handler.claim("5f00000000000000000000a6", start)).To(Equal(claimed))
+			handler.done("5f00000000000000000000a6", start, true)
+			Expect(handler.claim("5f00000000000000000000a6", start.Add(DefaultReplayWindow/2))).To(Equal(claimDelivered))
+			Expect(handler.claim("5f00000000000000000000a6", start.Add(2*DefaultReplayWindow))).To(Equal(claimed))
+		})
+
+		g.It("should allow redelivery when a callback fails", func() {
+			failures = 1
+			body := webhookPayload("5f00000000000000000000a7", time.Now())
+			Expect(serve(signed(body))).To(Equal(http.StatusInternalServerError))
+			Expect(events).To(BeEmpty())
+			Expect(serve(signed(body))).To(Equal(http.StatusOK))
+			Expect(len(events)).To(Equal(1))
+		})
+
+		g.It("should have redeliveries retried while the action is being handled", func() {
+			entered, release := make(chan bool), make(chan bool)
+			handler.Handle(func(ctx context.Context, event *WebhookEvent) error {
+				if len(events) == 1 {
+					entered <- true
+					<-release
+					return errors.New("failure after a slow start")
+				}
+				return nil
+			})
+			body := webhookPayload("5f00000000000000000000a8", time.Now())
+			first := make(chan int)
+			go func() { first <- serve(signed(body)) }()
+			<-entered
+			Expect(serve(signed(body))).To(Equal(http.StatusConflict))
+			close(release)
+			Expect(<-first).To(Equal(http.StatusInternalServerError))
+
+			// The failed delivery was not recorded, so the next one is dispatched
+			Expect(serve(signed(body))).To(Equal(http.StatusOK))
+			Expect(len(events)).To(Equal(2))
+			Expect(serve(signed(body))).To(Equal(http.StatusOK))
+			Expect(len(events)).To(Equal(2))
+		})
+
+		g.It("should reject malformed payloads", func() {
+			Expect(serve(signed(`{"action": `))).To(Equal(http.StatusBadRequest))
+			Expect(serve(signed(`{"model": {"id": "5f0000000000000000000004"}}`))).To(Equal(http.StatusBadRequest))
+		})
+	})
+}
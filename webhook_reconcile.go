@@ -0,0 +1,186 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trello
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// WebhookOp - Kind of change ReconcileWebhooks makes to a registration
+type WebhookOp string
+
+// Webhook changes
+const (
+	WebhookCreate     WebhookOp = "create"
+	WebhookUpdate     WebhookOp = "update"
+	WebhookReactivate WebhookOp = "reactivate"
+	WebhookDelete     WebhookOp = "delete"
+)
+
+// WebhookChange - A change ReconcileWebhooks made (or would make, in a dry run)
+type WebhookChange struct {
+	Op WebhookOp
+	// Before - The registration before the change (nil when creating)
+	Before *Webhook
+	// After - The registration after the change (nil when deleting)
+	After *Webhook
+	// Err - Why the change failed, if it did
+	Err error
+}
+
+// String - Describe the change, e.g. "update 5f... description "old" -> "new""
+func (ch WebhookChange) String() string {
+	switch {
+	case ch.Before == nil:
+		return fmt.Sprintf("%s webhook for %s -> %s (%q)", ch.Op, ch.After.IDModel, ch.After.CallbackURL, ch.After.Description)
+	case ch.After == nil:
+		return fmt.Sprintf("%s webhook %s for %s -> %s", ch.Op, ch.Before.ID, ch.Before.IDModel, ch.Before.CallbackURL)
+	}
+	s := fmt.Sprintf("%s webhook %s for %s", ch.Op, ch.Before.ID, ch.Before.IDModel)
+	if ch.Before.CallbackURL != ch.After.CallbackURL {
+		s += fmt.Sprintf(" callbackURL %q -> %q", ch.Before.CallbackURL, ch.After.CallbackURL)
+	}
+	if ch.Before.Description != ch.After.Description {
+		s += fmt.Sprintf(" description %q -> %q", ch.Before.Description, ch.After.Description)
+	}
+	if ch.Before.Active != ch.After.Active {
+		s += fmt.Sprintf(" active %v -> %v", ch.Before.Active, ch.After.Active)
+	}
+	return s
+}
+
+// ReconcileOptions - Options for ReconcileWebhooks
+type ReconcileOptions struct {
+	// DryRun - Only report the changes, without making them
+	DryRun bool
+	// Owns - Whether an existing registration is managed by this reconcile (all of them if nil)
+	// Registrations not owned are never updated or deleted.
+	Owns func(webhook Webhook) bool
+}
+
+// ReconcileWebhooks - Make the webhooks of a token match the desired registrations
+// Each desired Webhook needs IDModel and CallbackURL; Description is kept in sync too.
+// Registrations are matched on IDModel and CallbackURL, then on IDModel alone (updating
+// the callback URL in place). Inactive matches, e.g. deactivated by Trello after failed
+// deliveries, are reactivated; owned registrations left unmatched are deleted.
+// Every change is attempted: err joins the errors also set on the failed changes.
+func (c *Client) ReconcileWebhooks(token string, desired []Webhook, opts ReconcileOptions) (changes []WebhookChange, err error) {
+	return c.ReconcileWebhooksContext(context.Background(), token, desired, opts)
+}
+
+// ReconcileWebhooksContext - Make the webhooks of a token match the desired registrations (with context)
+func (c *Client) ReconcileWebhooksContext(ctx context.Context, token string, desired []Webhook, opts ReconcileOptions) (changes []WebhookChange, err error) {
+	existing, err := c.WebhooksContext(ctx, token)
+	if err != nil {
+		return
+	}
+	changes = planWebhooks(existing, desired, opts.Owns)
+	if opts.DryRun {
+		return
+	}
+	var errs []error
+	for i := range changes {
+		if changes[i].Err = c.applyWebhookChange(ctx, &changes[i]); changes[i].Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", changes[i], changes[i].Err))
+		}
+	}
+	return changes, errors.Join(errs...)
+}
+
+// planWebhooks - Changes turning existing into desired
+func planWebhooks(existing, desired []Webhook, owns func(Webhook) bool) (changes []WebhookChange) {
+	var owned []*Webhook
+	for i := range existing {
+		if owns == nil || owns(existing[i]) {
+			owned = append(owned, &existing[i])
+		}
+	}
+	matched := make([]*Webhook, len(desired))
+	take := func(match func(w *Webhook) bool) *Webhook {
+		for i, w := range owned {
+			if w != nil && match(w) {
+				owned[i] = nil
+				return w
+			}
+		}
+		return nil
+	}
+	for i, d := range desired {
+		matched[i] = take(func(w *Webhook) bool { return w.IDModel == d.IDModel && w.CallbackURL == d.CallbackURL })
+	}
+	for i, d := range desired {
+		if matched[i] == nil {
+			matched[i] = take(func(w *Webhook) bool { return w.IDModel == d.IDModel })
+		}
+	}
+
+	for i, d := range desired {
+		before := matched[i]
+		if before == nil {
+			after := Webhook{IDModel: d.IDModel, CallbackURL: d.CallbackURL, Description: d.Description, Active: true}
+			changes = append(changes, WebhookChange{Op: WebhookCreate, After: &after})
+			continue
+		}
+		after := *before
+		after.CallbackURL, after.Description, after.Active = d.CallbackURL, d.Description, true
+		switch {
+		case !before.Active:
+			changes = append(changes, WebhookChange{Op: WebhookReactivate, Before: before, After: &after})
+		case after != *before:
+			changes = append(changes, WebhookChange{Op: WebhookUpdate, Before: before, After: &after})
+		}
+	}
+	for _, w := range owned {
+		if w != nil {
+			changes = append(changes, WebhookChange{Op: WebhookDelete, Before: w})
+		}
+	}
+	return
+}
+
+// applyWebhookChange - Make the change, setting After to the registration returned by Trello
+func (c *Client) applyWebhookChange(ctx context.Context, ch *WebhookChange) (err error) {
+	switch ch.Op {
+	case WebhookCreate:
+		var created *Webhook
+		if created, err = c.CreateWebhookContext(ctx, *ch.After); err == nil {
+			ch.After = created
+		}
+	case WebhookDelete:
+		err = c.DeleteWebhookContext(ctx, ch.Before.ID)
+	default:
+		payload := url.Values{}
+		if ch.After.CallbackURL != ch.Before.CallbackURL {
+			payload.Set("callbackURL", ch.After.CallbackURL)
+		}
+		if ch.After.Description != ch.Before.Description {
+			payload.Set("description", ch.After.Description)
+		}
+		if !ch.Before.Active {
+			payload.Set("active", "true")
+		}
+		updated := *ch.Before
+		updated.client = c
+		if err = updated.update(ctx, payload); err == nil {
+			ch.After = &updated
+		}
+	}
+	return
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trello
+
+import (
+	"strings"
+	"testing"
+
+	goblin "github.com/franela/goblin"
+	. "github.com/onsi/gomega"
+)
+
+func TestReconcileWebhooks(t *testing.T) {
+	g := goblin.Goblin(t)
+	RegisterFailHandler(func(m string, _ ...int) { g.Fail(m) })
+
+	g.Describe("Webhook reconcile tests", func() {
+		var board *Board
+		var member *Member
+		var prefix string
+		var opts ReconcileOptions
+
+		ops := func(changes []WebhookChange) (result []WebhookOp) {
+			for _, ch := range changes {
+				result = append(result, ch.Op)
+			}
+			return
+		}
+
+		owned := func() (hooks []Webhook) {
+			webhooks, err := client.Webhooks(apiToken)
+			Expect(err).To(BeNil())
+			for _, w := range webhooks {
+				if opts.Owns(w) {
+					hooks = append(hooks, w)
+				}
+			}
+			return
+		}
+
+		g.Before(func() {
+			board, err = client.CreateBoard(testName("GoTestTrello-Reconcile"))
+			Expect(err).To(BeNil())
+			member, err = client.Member("me")
+			Expect(err).To(BeNil())
+			prefix = "https://www.google.com/?q=" + testName("go-trello-reconcile")
+			// Leave webhooks of other tests and applications alone
+			opts = ReconcileOptions{Owns: func(w Webhook) bool { return strings.HasPrefix(w.CallbackURL, prefix) }}
+		})
+
+		g.It("should set the client on listed webhooks", func() {
+			hook, err := client.CreateWebhook(Webhook{IDModel: board.ID, CallbackURL: prefix + "-listed"})
+			Expect(err).To(BeNil())
+			webhooks := owned()
+			Expect(len(webhooks)).To(Equal(1))
+			Expect(webhooks[0].client).To(Equal(client))
+			Expect(webhooks[0].SetActive(false)).To(BeNil())
+			Expect(hook.Delete()).To(BeNil())
+		})
+
+		g.It("should create the desired webhooks", func() {
+			desired := []Webhook{
+				{IDModel: board.ID, CallbackURL: prefix + "-board", Description: "Board"},
+				{IDModel: member.ID, CallbackURL: prefix + "-member", Description: "Member"},
+			}
+			opts.DryRun = true
+			changes, err := client.ReconcileWebhooks(apiToken, desired, opts)
+			Expect(err).To(BeNil())
+			Expect(ops(changes)).To(Equal([]WebhookOp{WebhookCreate, WebhookCreate}))
+			Expect(changes[0].String()).To(ContainSubstring("create webhook for " + board.ID))
+			Expect(owned()).To(BeEmpty())
+
+			opts.DryRun = false
+			changes, err = client.ReconcileWebhooks(apiToken, desired, opts)
+			Expect(err).To(BeNil())
+			Expect(len(changes)).To(Equal(2))
+			Expect(changes[0].After.ID).NotTo(BeEmpty())
+			Expect(len(owned())).To(Equal(2))
+
+			changes, err = client.ReconcileWebhooks(apiToken, desired, opts)
+			Expect(err).To(BeNil())
+			Expect(changes).To(BeEmpty())
+		})
+
+		g.It("should update, reactivate and delete webhooks", func() {
+			for _, w := range owned() {
+				if w.IDModel == member.ID {
+					Expect(w.SetActive(false)).To(BeNil())
+				}
+			}
+			desired := []Webhook{
+				{IDModel: board.ID, CallbackURL: prefix + "-board-v2", Description: "Board v2"},
+				{IDModel: member.ID, CallbackURL: prefix + "-member", Description: "Member"},
+			}
+			changes, err := client.ReconcileWebhooks(apiToken, desired, opts)
+			Expect(err).To(BeNil())
+			Expect(ops(changes)).To(Equal([]WebhookOp{WebhookUpdate, WebhookReactivate}))
+			Expect(changes[0].String()).To(ContainSubstring(`description "Board" -> "Board v2"`))
+			Expect(changes[0].After.CallbackURL).To(Equal(prefix + "-board-v2"))
+			Expect(changes[1].After.Active).To(BeTrue())
+			for _, w := range owned() {
+				Expect(w.Active).To(BeTrue())
+			}
+
+			changes, err = client.ReconcileWebhooks(apiToken, desired[1:], opts)
+			Expect(err).To(BeNil())
+			Expect(ops(changes)).To(Equal([]WebhookOp{WebhookDelete}))
+			Expect(changes[0].Before.IDModel).To(Equal(board.ID))
+			Expect(len(owned())).To(Equal(1))
+		})
+
+		g.It("should report failed changes", func() {
+			changes, err := client.ReconcileWebhooks(apiToken, []Webhook{
+				{IDModel: member.ID, CallbackURL: prefix + "-member", Description: "Member"},
+				{IDModel: board.ID, CallbackURL: "not a url"},
+			}, opts)
+			Expect(err).NotTo(BeNil())
+			Expect(len(changes)).To(Equal(1))
+			Expect(changes[0].Op).To(Equal(WebhookCreate))
+			Expect(changes[0].Err).NotTo(BeNil())
+		})
+
+		g.After(func() {
+			_, err = client.ReconcileWebhooks(apiToken, nil, opts)
+			Expect(err).To(BeNil())
+			Expect(owned()).To(BeEmpty())
+			err = board.Delete()
+			Expect(err).To(BeNil())
+		})
+	})
+}
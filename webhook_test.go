@@ -17,9 +17,6 @@ limitations under the License.
 package trello
 
 import (
-	"fmt"
-	"math/rand"
-	"os"
 	"testing"
 
 	goblin "github.com/franela/goblin"
@@ -36,9 +33,9 @@ func TestWebhook(t *testing.T) {
 		var callbackURL string
 
 		g.Before(func() {
-			token = os.Getenv("API_TOKEN")
+			token = apiToken
 			Expect(token).NotTo(BeEmpty())
-			callbackURL = fmt.Sprintf("https://www.google.com/?q=go-trello-test-%v", rand.Intn(65536))
+			callbackURL = "https://www.google.com/?q=" + testName("go-trello-test")
 		})
 
 		g.It("should create a webhook", func() {